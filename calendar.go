@@ -3,6 +3,7 @@ package giowidgets
 import (
 	"fmt"
 	"gioui.org/font/gofont"
+	"gioui.org/io/key"
 	"gioui.org/layout"
 	"gioui.org/op"
 	"gioui.org/op/clip"
@@ -11,18 +12,54 @@ import (
 	"gioui.org/unit"
 	"gioui.org/widget"
 	"gioui.org/widget/material"
-	"golang.org/x/exp/shiny/materialdesign/colornames"
 	"golang.org/x/exp/shiny/materialdesign/icons"
+	"golang.org/x/text/language"
 	"image"
-	"image/color"
-	"strings"
 	"time"
 )
 
 type OnCalendarDateClick func(t time.Time)
 
+type OnRangeSelected func(start, end time.Time)
+
+// SelectionMode defines how clicks on the days of the Calendar update its selection.
+type SelectionMode int
+
+const (
+	// SelectionSingle keeps at most one selected day.
+	SelectionSingle SelectionMode = iota
+	// SelectionRange selects a start day with the first click and an end day with the second one.
+	SelectionRange
+	// SelectionMultiple toggles the clicked day in and out of the selection.
+	SelectionMultiple
+)
+
+// CellState describes a day cell of the Calendar to a CellRenderer.
+type CellState struct {
+	Date    time.Time
+	InMonth bool
+	Today   bool
+	Hovered bool
+	// Selected is set for the selected days and for both ends of a range selection.
+	Selected bool
+	// InRange is set for the days between the start and the end of a range selection.
+	InRange  bool
+	Disabled bool
+	Focused  bool
+	Weekend  bool
+	// Density is the density of the grid, CellRenderer can drop details from compact cells.
+	Density CalendarDensity
+	// Holiday names the holidays of the day given by the HolidayProvider, it is empty on the other days.
+	Holiday string
+}
+
+// CellRenderer draws the content of a day cell. The constraints are set to the size of the cell,
+// while the Calendar keeps handling the clicks, the focus and the layout of the cells.
+type CellRenderer func(gtx Gtx, cell CellState) Dim
+
 type monthButton struct {
-	Month time.Month
+	// Month is the number of the month in the CalendarSystem, starting at 1.
+	Month int
 	widget.Clickable
 }
 
@@ -34,49 +71,145 @@ type yearButton struct {
 type cellItem struct {
 	widget.Clickable
 	time.Time
+	// inMonth is set when the day belongs to the month of its grid
+	inMonth bool
+	holiday string
 }
 
-// space between months and years dropdown in the header
-var spaceBetweenHeaderDropdowns = unit.Dp(32)
-
-const dropdownWidth = unit.Dp(120)
-
-var allMonthsButtonsArr = [12]monthButton{
-	{Month: 1}, {Month: 2}, {Month: 3}, {Month: 4},
-	{Month: 5}, {Month: 6}, {Month: 7}, {Month: 8},
-	{Month: 9}, {Month: 10}, {Month: 11}, {Month: 12},
-}
-var allYearsButtonsSlice []yearButton
-var monthsHeaderRowHeight = unit.Dp(64)
-var viewHeaderHeight = unit.Dp(32)
-
-func SetAllYearsButtonsSlice(startTime, endTime time.Time) {
-	allYearsButtonsSlice = GetYearsRangeButtons(startTime.Year(), endTime.Year())
-}
-
-func init() {
-	startTime := time.Now().AddDate(-100, 0, 0)
-	endTime := time.Now().AddDate(101, 0, 0)
-	SetAllYearsButtonsSlice(startTime, endTime)
-}
+const (
+	defaultDropdownWidth         = unit.Dp(120)
+	defaultMonthsHeaderRowHeight = unit.Dp(64)
+	defaultViewHeaderHeight      = unit.Dp(32)
+)
 
+// Calendar holds the state of a month calendar, see CalendarStyle for its presentation.
 type Calendar struct {
-	Theme              *material.Theme
-	time               time.Time
-	btnDropdownMonth   widget.Clickable
-	monthsList         layout.List
-	yearsList          layout.List
-	btnDropdownYear    widget.Clickable
-	initialized        bool
-	ShowMonthsDropdown bool
-	showYearsDropdown  bool
-	fullView           widget.Clickable
-	viewList           layout.List
+	// Theme is used by Calendar.Layout.
+	//
+	// Deprecated: draw the Calendar with a CalendarStyle built by NewCalendarStyle instead.
+	Theme            *material.Theme
+	time             time.Time
+	btnDropdownMonth widget.Clickable
+	monthsList       layout.List
+	yearsList        layout.List
+	btnDropdownYear  widget.Clickable
+	btnPrevMonth     widget.Clickable
+	btnNextMonth     widget.Clickable
+	btnPrevYear      widget.Clickable
+	btnNextYear      widget.Clickable
+	// ShowYearNavigation adds buttons moving one year backward and forward to the header.
+	ShowYearNavigation bool
+	// ZoomableTitle shows a title zooming out to the year and decade grids in the header instead of
+	// the months and years dropdowns.
+	ZoomableTitle bool
+	// View is the zoom level of the body, the header title and picking an item of a grid change it.
+	View        CalendarView
+	btnZoomOut  widget.Clickable
+	zoomButtons []widget.Clickable
+	// SlotLength is the length of the time slots of the week and day views, 15, 30 (the default) or
+	// 60 minutes. Other lengths are rounded to the nearest of them.
+	SlotLength time.Duration
+	// WorkingHoursStart and WorkingHoursEnd are the offsets from midnight of the working hours,
+	// the other slots and the weekends are shaded. The week and day views first scroll to WorkingHoursStart.
+	WorkingHoursStart time.Duration
+	WorkingHoursEnd   time.Duration
+	OnSlotClick
+	slotList      layout.List
+	slotsScrolled bool
+	// slotButtons are indexed by the slot and the day of the week view
+	slotButtons   []widget.Clickable
+	timelineChips eventChips
+	allDayChips   eventChips
+	// Transition animates the grids when Time moves to another month, ReducedMotion turns it off.
+	Transition CalendarTransition
+	// TransitionDuration defaults to 250ms.
+	TransitionDuration time.Duration
+	ReducedMotion      bool
+	// shownTime is the Time of the last frame, the transition runs from transitionFrom to Time
+	shownTime           time.Time
+	transitionFrom      time.Time
+	transitionStart     time.Time
+	transitionDirection int
+	transitionViews     []*monthView
+	initialized         bool
+	ShowMonthsDropdown  bool
+	showYearsDropdown   bool
+	fullView            widget.Clickable
+	viewList            layout.List
 	OnCalendarDateClick
-	weekdays       [7]time.Weekday
+	OnRangeSelected
+	SelectionMode
+	// MinDate and MaxDate bound the days that can be selected, a zero value leaves that side unbounded.
+	MinDate time.Time
+	MaxDate time.Time
+	// IsDateDisabled optionally disables additional days within the bounds.
+	IsDateDisabled func(t time.Time) bool
+	// DayDecorator optionally adds dots, a badge or an underline to the days.
+	DayDecorator DayDecorator
+	// HolidayProvider optionally marks the holidays, their names are shown when hovering their days.
+	HolidayProvider HolidayProvider
+	// EventSource optionally provides the events drawn in the cells of the month grids and, for
+	// the timed ones, in the time slots of the week and day views.
+	EventSource EventSource
+	OnEventClick
+	OnMoreEventsClick
+	// OnEventMoved is called when an event is dragged to another day, or time slot of the week and
+	// day views. Dragging is enabled by OnEventMoved or by an EventSource implementing EventMover.
+	OnEventMoved
+	eventDrag eventDrag
+	// CellRenderer optionally replaces the default drawing of the day cells.
+	CellRenderer
+	// ShowWeekNumbers adds a leading column displaying the number of each week.
+	ShowWeekNumbers bool
+	WeekNumbering
+	OnWeekClick
+	// MonthsToShow displays several consecutive months, starting with the month of Time.
+	MonthsToShow int
+	// MonthsPerRow limits the number of months laid out side by side, zero lays them all out in a single row.
+	MonthsPerRow  int
+	monthViews    []*monthView
+	selectedDates []time.Time
+	rangeStart    time.Time
+	rangeEnd      time.Time
+	hoveredDate   time.Time
+	// monthsButtons and yearsButtonsSlice are the entries of the months and years dropdowns
+	monthsButtons         []monthButton
+	yearsButtonsSlice     []yearButton
+	yearsRangeStart       time.Time
+	yearsRangeEnd         time.Time
+	yearsSystem           CalendarSystem
+	monthsHeaderRowHeight unit.Dp
+	viewHeaderHeight      unit.Dp
+	dropdownWidth         unit.Dp
+	weekdays              [7]time.Weekday
+	// FirstDayOfWeek starts the weeks on the given day, unless LocaleFirstDay is set.
 	FirstDayOfWeek time.Weekday
-	cellItemsArr   []*cellItem
-	maxWidth       int
+	// LocaleFirstDay starts the weeks on the first day of the week of the Locale instead of FirstDayOfWeek.
+	LocaleFirstDay bool
+	// Locale selects the names of the months and weekdays, the first day of the week used with LocaleFirstDay
+	// and the weekend days. The zero value uses American English.
+	Locale         language.Tag
+	locale         Locale
+	localeResolved bool
+	// CalendarSystem numbers the months and years of the grid, nil uses the Gregorian calendar.
+	CalendarSystem CalendarSystem
+	// Clock tells the current time, nil uses SystemClock.
+	Clock Clock
+	// Location is the time zone of the grid and of today, nil uses the local time zone.
+	Location *time.Location
+	// today is read from the Clock once per frame
+	today time.Time
+	// keyTag receives the key events while the Calendar has the focus
+	keyTag       bool
+	focused      bool
+	requestFocus bool
+	focusedDate  time.Time
+	maxWidth     int
+	// gridWidth is the width of a single month grid
+	gridWidth int
+	// cellSize is the size of the day cells, filling the grids within the cell size bounds of the style
+	cellSize image.Point
+	// Deprecated: set the Inset of the CalendarStyle instead, NewCalendarStyle copies it.
 	layout.Inset
 }
 
@@ -87,37 +220,280 @@ func (c *Calendar) Time() time.Time {
 	return c.time
 }
 
+// SetYearsRange sets the years listed in the years dropdown, from the year of startTime
+// upto but not including the year of endTime.
+func (c *Calendar) SetYearsRange(startTime, endTime time.Time) {
+	c.yearsRangeStart, c.yearsRangeEnd = startTime, endTime
+	c.yearsButtonsSlice = nil
+}
+
+// SetMonthsHeaderRowHeight sets the height of the row displaying the weekdays.
+//
+// Deprecated: set the MonthsHeaderRowHeight of the CalendarStyle instead, NewCalendarStyle copies it.
+func (c *Calendar) SetMonthsHeaderRowHeight(height unit.Dp) {
+	c.monthsHeaderRowHeight = height
+}
+
+// SetViewHeaderHeight sets the height of the header displaying the months and years dropdowns.
+//
+// Deprecated: set the ViewHeaderHeight of the CalendarStyle instead, NewCalendarStyle copies it.
+func (c *Calendar) SetViewHeaderHeight(height unit.Dp) {
+	c.viewHeaderHeight = height
+}
+
+// SetDropdownWidth sets the width of the months and years dropdowns.
+//
+// Deprecated: set the DropdownWidth of the CalendarStyle instead, NewCalendarStyle copies it.
+func (c *Calendar) SetDropdownWidth(width unit.Dp) {
+	c.dropdownWidth = width
+}
+
+// Selected returns the selected days in the order they were selected.
+// In SelectionRange mode it returns the start and, once chosen, the end of the range.
+func (c *Calendar) Selected() []time.Time {
+	if c.SelectionMode == SelectionRange {
+		if c.rangeStart.IsZero() {
+			return nil
+		}
+		if c.rangeEnd.IsZero() {
+			return []time.Time{c.rangeStart}
+		}
+		return []time.Time{c.rangeStart, c.rangeEnd}
+	}
+	return append([]time.Time(nil), c.selectedDates...)
+}
+
+// SelectedRange returns the range chosen in SelectionRange mode.
+// end is zero while the range is still waiting for its second click.
+func (c *Calendar) SelectedRange() (start, end time.Time) {
+	return c.rangeStart, c.rangeEnd
+}
+
+// SetSelectedRange selects the days between start and end, both inclusive.
+func (c *Calendar) SetSelectedRange(start, end time.Time) {
+	if compareDays(end, start) < 0 {
+		start, end = end, start
+	}
+	c.rangeStart, c.rangeEnd = start, end
+}
+
+// Select selects the day t as a click on it would, following the SelectionMode and calling
+// OnRangeSelected when it ends a range. It reports false and leaves the selection unchanged when t
+// is disabled.
+func (c *Calendar) Select(t time.Time) bool {
+	if c.isDisabled(t) {
+		return false
+	}
+	c.selectDate(t)
+	return true
+}
+
+// ClearSelection removes all the selected days.
+func (c *Calendar) ClearSelection() {
+	c.selectedDates = nil
+	c.rangeStart, c.rangeEnd = time.Time{}, time.Time{}
+}
+
+// resolvedLocale returns the Locale matching c.Locale, resolving it again only when the tag changes.
+func (c *Calendar) resolvedLocale() Locale {
+	if !c.localeResolved || c.locale.Tag != c.Locale {
+		c.locale = LocaleFor(c.Locale)
+		c.locale.Tag = c.Locale
+		c.localeResolved = true
+	}
+	return c.locale
+}
+
+// firstDayOfWeek returns FirstDayOfWeek, or the first day of the week of the Locale when LocaleFirstDay is set.
+func (c *Calendar) firstDayOfWeek() time.Weekday {
+	if c.LocaleFirstDay {
+		return c.resolvedLocale().FirstDayOfWeek
+	}
+	return c.FirstDayOfWeek
+}
+
+func (c *Calendar) weekModel() weekModel {
+	return weekModel{start: c.firstDayOfWeek()}
+}
+
+// system returns the CalendarSystem, defaulting to Gregorian.
+func (c *Calendar) system() CalendarSystem {
+	if c.CalendarSystem == nil {
+		return Gregorian
+	}
+	return c.CalendarSystem
+}
+
+// date returns t in the numbering of the CalendarSystem.
+func (c *Calendar) date(t time.Time) CalendarDate {
+	return c.system().FromTime(t)
+}
+
+// isDisabled reports whether the day t is outside MinDate and MaxDate or rejected by IsDateDisabled.
+func (c *Calendar) isDisabled(t time.Time) bool {
+	if !c.MinDate.IsZero() && compareDays(t, c.MinDate) < 0 {
+		return true
+	}
+	if !c.MaxDate.IsZero() && compareDays(t, c.MaxDate) > 0 {
+		return true
+	}
+	return c.IsDateDisabled != nil && c.IsDateDisabled(t)
+}
+
+// isOutOfBounds reports whether the days from first to last all lie outside of MinDate and MaxDate.
+func (c *Calendar) isOutOfBounds(first, last time.Time) bool {
+	if !c.MinDate.IsZero() && compareDays(last, c.MinDate) < 0 {
+		return true
+	}
+	return !c.MaxDate.IsZero() && compareDays(first, c.MaxDate) > 0
+}
+
+// isMonthDisabled reports whether every day of the month of t is outside MinDate and MaxDate.
+func (c *Calendar) isMonthDisabled(t time.Time) bool {
+	if !c.MinDate.IsZero() && compareDays(endOfMonth(c.system(), t), c.MinDate) < 0 {
+		return true
+	}
+	return !c.MaxDate.IsZero() && compareDays(beginningOfMonth(c.system(), t), c.MaxDate) > 0
+}
+
+// clampTime keeps t between MinDate and MaxDate, preserving the time of the day.
+func (c *Calendar) clampTime(t time.Time) time.Time {
+	bound := t
+	if !c.MinDate.IsZero() && compareDays(t, c.MinDate) < 0 {
+		bound = c.MinDate
+	}
+	if !c.MaxDate.IsZero() && compareDays(t, c.MaxDate) > 0 {
+		bound = c.MaxDate
+	}
+	return withClock(bound, t)
+}
+
+// monthButtons returns the months dropdown entries of the displayed year.
+func (c *Calendar) monthButtons() []monthButton {
+	months := c.system().MonthsInYear(c.date(c.Time()).Year)
+	for len(c.monthsButtons) < months {
+		c.monthsButtons = append(c.monthsButtons, monthButton{Month: len(c.monthsButtons) + 1})
+	}
+	return c.monthsButtons[:months]
+}
+
+// yearsButtons returns the years dropdown entries which lie within MinDate and MaxDate,
+// numbered by the CalendarSystem.
+func (c *Calendar) yearsButtons() []yearButton {
+	if c.yearsButtonsSlice == nil || c.yearsSystem != c.system() {
+		c.yearsSystem = c.system()
+		c.yearsButtonsSlice = GetYearsRangeButtons(c.date(c.yearsRangeStart).Year, c.date(c.yearsRangeEnd).Year)
+	}
+	start, end := 0, len(c.yearsButtonsSlice)
+	for start < end && !c.MinDate.IsZero() && c.yearsButtonsSlice[start].Year < c.date(c.MinDate).Year {
+		start++
+	}
+	for end > start && !c.MaxDate.IsZero() && c.yearsButtonsSlice[end-1].Year > c.date(c.MaxDate).Year {
+		end--
+	}
+	return c.yearsButtonsSlice[start:end]
+}
+
+// onDateClick reports the click on the day t and updates the selection.
+func (c *Calendar) onDateClick(t time.Time) {
+	if c.OnCalendarDateClick != nil {
+		c.OnCalendarDateClick(t)
+	}
+	c.selectDate(t)
+}
+
+func (c *Calendar) selectDate(t time.Time) {
+	switch c.SelectionMode {
+	case SelectionRange:
+		if c.rangeStart.IsZero() || !c.rangeEnd.IsZero() {
+			c.rangeStart, c.rangeEnd = t, time.Time{}
+			return
+		}
+		c.SetSelectedRange(c.rangeStart, t)
+		if c.OnRangeSelected != nil {
+			c.OnRangeSelected(c.rangeStart, c.rangeEnd)
+		}
+	case SelectionMultiple:
+		for i, selected := range c.selectedDates {
+			if sameDay(selected, t) {
+				c.selectedDates = append(c.selectedDates[:i], c.selectedDates[i+1:]...)
+				return
+			}
+		}
+		c.selectedDates = append(c.selectedDates, t)
+	default:
+		c.selectedDates = []time.Time{t}
+	}
+}
+
+func (c *Calendar) isSelected(t time.Time) bool {
+	if c.SelectionMode == SelectionRange {
+		return (!c.rangeStart.IsZero() && sameDay(c.rangeStart, t)) ||
+			(!c.rangeEnd.IsZero() && sameDay(c.rangeEnd, t))
+	}
+	for _, selected := range c.selectedDates {
+		if sameDay(selected, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// isInRange reports whether t lies within the selected range. While the end of the range
+// is still pending, the hovered day is used as its end to preview the range.
+func (c *Calendar) isInRange(t time.Time) bool {
+	if c.SelectionMode != SelectionRange || c.rangeStart.IsZero() {
+		return false
+	}
+	start, end := c.rangeStart, c.rangeEnd
+	if end.IsZero() {
+		end = c.hoveredDate
+	}
+	if end.IsZero() {
+		return false
+	}
+	if compareDays(end, start) < 0 {
+		start, end = end, start
+	}
+	return compareDays(t, start) >= 0 && compareDays(t, end) <= 0
+}
+
+// Layout draws the Calendar with the default CalendarStyle of Theme.
+//
+// Deprecated: use NewCalendarStyle(th, c).Layout(gtx) instead.
 func (c *Calendar) Layout(gtx Gtx) Dim {
+	if c.Theme == nil {
+		c.Theme = material.NewTheme(gofont.Collection())
+	}
+	return NewCalendarStyle(c.Theme, c).Layout(gtx)
+}
+
+func (s *CalendarStyle) layout(gtx Gtx) Dim {
+	c := s.Calendar
 	if !c.initialized {
-		now := time.Now()
+		now := c.now()
 		if c.Time().IsZero() {
-			c.SetTime(now)
+			c.SetTime(c.clampTime(now))
 		}
-		c.cellItemsArr = make([]*cellItem, 0)
-		for i := 0; i < 42; i++ {
-			c.cellItemsArr = append(c.cellItemsArr, &cellItem{})
+		if c.yearsRangeStart.IsZero() && c.yearsRangeEnd.IsZero() {
+			c.SetYearsRange(now.AddDate(-100, 0, 0), now.AddDate(101, 0, 0))
 		}
-		c.weekdays = [7]time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday}
 		c.initialized = true
 	}
-	if c.Theme == nil {
-		c.Theme = material.NewTheme(gofont.Collection())
-	}
-	if gtx.Constraints.Max.X > gtx.Constraints.Max.Y {
-		gtx.Constraints.Max.X = gtx.Constraints.Max.Y
+	if t := c.Time(); c.Location != nil && t.Location() != c.Location {
+		c.SetTime(t.In(c.Location))
 	}
+	c.today = c.Today()
+	c.invalidateAtMidnight(gtx)
+	perRow := c.monthsPerRow()
+	c.maxWidth = gtx.Constraints.Max.X - gtx.Dp(s.Inset.Left+s.Inset.Right)
+	c.gridWidth = (c.maxWidth - gtx.Dp(s.MonthSpacing)*(perRow-1)) / perRow
 
-	c.maxWidth = gtx.Constraints.Max.X - gtx.Dp(c.Inset.Left+c.Inset.Right)
-
-	firstDay := int(c.FirstDayOfWeek)
-	c.weekdays[0] = c.FirstDayOfWeek
-	for i := 1; i < 7; i++ {
-		firstDay++
-		firstDay %= 7
-		c.weekdays[i] = time.Weekday(firstDay)
-	}
+	c.weekdays = c.weekModel().weekdays()
+	c.processKeys(gtx)
 
 	if c.fullView.Clicked() {
+		c.requestFocus = true
 		if !c.btnDropdownMonth.Pressed() {
 			c.ShowMonthsDropdown = false
 		}
@@ -126,222 +502,324 @@ func (c *Calendar) Layout(gtx Gtx) Dim {
 		}
 	}
 	d := c.fullView.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-		return c.Inset.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return s.Inset.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 			flex := Flex{Axis: layout.Vertical}
 			return flex.Layout(gtx,
-				layout.Rigid(c.drawViewHeader),
-				layout.Rigid(c.drawHeaderRow),
-				layout.Rigid(c.drawBodyRows),
+				layout.Rigid(s.drawViewHeader),
+				layout.Rigid(s.drawBody),
 			)
 		})
 	})
 
 	if c.ShowMonthsDropdown {
-		c.drawMonthsDropdownItems(gtx)
+		s.drawMonthsDropdownItems(gtx)
 	}
 	if c.showYearsDropdown {
-		c.drawYearsDropdownItems(gtx)
+		s.drawYearsDropdownItems(gtx)
+	}
+	key.InputOp{Tag: &c.keyTag, Keys: calendarKeys}.Add(gtx.Ops)
+	if c.requestFocus {
+		key.FocusOp{Tag: &c.keyTag}.Add(gtx.Ops)
+		c.requestFocus = false
 	}
 	return d
 }
 
-func (c *Calendar) drawHeaderRow(gtx Gtx) Dim {
-	var flexChildren = make([]FlexChild, len(c.weekdays))
-	columnWidth := c.maxWidth / 7
-	for i, day := range c.weekdays {
-		dayStr := strings.ToUpper(day.String()[0:3])
-		flexChildren[i] = c.drawHeaderColumn(gtx, dayStr, columnWidth)
+func (s *CalendarStyle) drawHeaderRow(gtx Gtx) Dim {
+	c := s.Calendar
+	var flexChildren = make([]FlexChild, 0, c.columnsCount())
+	columnWidth := c.cellSize.X
+	locale := c.resolvedLocale()
+	if c.ShowWeekNumbers {
+		flexChildren = append(flexChildren, s.drawHeaderColumn(gtx, locale.WeekLabel, locale.WeekLabel, columnWidth))
 	}
+	for _, day := range c.weekdays {
+		label := locale.Upper(locale.ShortWeekdayNames[day])
+		if s.density(gtx) == DensityExpanded {
+			label = locale.WeekdayNames[day]
+		}
+		flexChildren = append(flexChildren, s.drawHeaderColumn(gtx, label, locale.NarrowWeekdayNames[day], columnWidth))
+	}
+	return s.layoutHeaderRow(gtx, flexChildren)
+}
+
+// layoutHeaderRow lays out the headings of a header row over its background.
+func (s *CalendarStyle) layoutHeaderRow(gtx Gtx, flexChildren []FlexChild) Dim {
 	flex := Flex{}
 	mac := op.Record(gtx.Ops)
 	d := flex.Layout(gtx, flexChildren...)
 	call := mac.Stop()
 	rect := clip.Rect{Max: d.Size}
-	paint.FillShape(gtx.Ops, c.Theme.ContrastBg, rect.Op())
+	paint.FillShape(gtx.Ops, s.HeaderBg, rect.Op())
 	call.Add(gtx.Ops)
 	return d
 }
-func (c *Calendar) drawHeaderColumn(gtx Gtx, day string, columnWidth int) FlexChild {
-	gtx.Constraints.Min.Y, gtx.Constraints.Max.Y = gtx.Dp(monthsHeaderRowHeight), gtx.Dp(monthsHeaderRowHeight)
+
+// drawHeaderColumn draws a heading of the header row, narrowLabel replaces label in the compact layout.
+func (s *CalendarStyle) drawHeaderColumn(gtx Gtx, label, narrowLabel string, columnWidth int) FlexChild {
+	gtx.Constraints.Min.Y, gtx.Constraints.Max.Y = gtx.Dp(s.MonthsHeaderRowHeight), gtx.Dp(s.MonthsHeaderRowHeight)
 	return layout.Rigid(func(gtx Gtx) Dim {
 		gtx.Constraints.Min.X, gtx.Constraints.Max.X = columnWidth, columnWidth
 		inset := layout.UniformInset(16)
-		if c.maxWidth < gtx.Dp(500) {
+		if s.compact(gtx) {
 			inset.Top, inset.Bottom, inset.Right, inset.Left = 8, 8, 8, 8
 		}
 		return inset.Layout(gtx, func(gtx Gtx) Dim {
 			return layout.Center.Layout(gtx, func(gtx Gtx) Dim {
-				label := material.Label(c.Theme, c.Theme.TextSize, day)
-				label.Color = c.Theme.ContrastFg
-				label.MaxLines = 1
-				if c.maxWidth < gtx.Dp(500) {
-					label.Text = day[:1]
-					label.TextSize = unit.Sp(14)
+				headingLabel := material.Label(s.Theme, s.TextSize, label)
+				headingLabel.Color = s.HeaderFg
+				headingLabel.MaxLines = 1
+				if s.compact(gtx) {
+					headingLabel.Text = narrowLabel
+					headingLabel.TextSize = s.CompactTextSize
 				}
-				return label.Layout(gtx)
+				return headingLabel.Layout(gtx)
 			})
 		})
 	})
 }
 
-func (c *Calendar) drawColumn(gtx Gtx, columnWidth int, btn *cellItem) FlexChild {
-	dayStr := fmt.Sprintf("%d", btn.Day())
+func (s *CalendarStyle) drawColumn(gtx Gtx, btn *cellItem) FlexChild {
+	c := s.Calendar
 	return layout.Rigid(func(gtx Gtx) Dim {
-		bgColor := c.Theme.Bg
-		txtColor := c.Theme.Fg
-		txtColor.A = 210
-		if btn.Month() != c.Time().Month() {
-			bgColor = color.NRGBA(colornames.BlueGrey50)
-			txtColor.A = 100
+		if btn.inMonth && !c.isDisabled(btn.Time) && btn.Clicked() {
+			c.focusedDate = btn.Time
+			c.requestFocus = true
+			c.onDateClick(btn.Time)
 		}
-		if c.Time().Month() == btn.Month() {
-			if btn.Clicked() {
-				if c.OnCalendarDateClick != nil {
-					c.OnCalendarDateClick(btn.Time)
-				}
-			}
-			now := time.Now()
-			isEqual := btn.Day() == now.Day() && now.Year() == btn.Year() && now.Month() == btn.Month()
-			if btn.Hovered() || isEqual {
-				bgColor = c.Theme.ContrastBg
-				bgColor.A = 240
-				txtColor = c.Theme.ContrastFg
-				txtColor.A = 240
+		cell := c.cellState(btn)
+		cell.Density = s.density(gtx)
+		if cell.Disabled {
+			gtx = gtx.Disabled()
+		}
+		d := btn.Layout(gtx, func(gtx Gtx) Dim {
+			gtx.Constraints.Min, gtx.Constraints.Max = c.cellSize, c.cellSize
+			if c.CellRenderer != nil {
+				return c.CellRenderer(gtx, cell)
 			}
+			return s.drawCell(gtx, cell)
+		})
+		if cell.Holiday != "" && btn.Hovered() {
+			s.drawTooltip(gtx, cell.Holiday, d.Size)
 		}
-		return btn.Layout(gtx, func(gtx Gtx) Dim {
-			mac := op.Record(gtx.Ops)
-			gtx.Constraints.Min.X, gtx.Constraints.Max.X = columnWidth, columnWidth
-			gtx.Constraints.Min.Y, gtx.Constraints.Max.Y = columnWidth, columnWidth
-			d := layout.UniformInset(unit.Dp(8)).Layout(gtx, func(gtx Gtx) Dim {
-				center := layout.N
-				txtSize := c.Theme.TextSize
-				txtSize *= 1.5
-				d := center.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-					label := material.Label(c.Theme, txtSize, dayStr)
+		return d
+	})
+}
+
+func (c *Calendar) cellState(btn *cellItem) CellState {
+	inMonth := btn.inMonth
+	disabled := c.isDisabled(btn.Time)
+	return CellState{
+		Date:     btn.Time,
+		InMonth:  inMonth,
+		Today:    sameDay(btn.Time, c.today),
+		Hovered:  inMonth && !disabled && btn.Hovered(),
+		Selected: inMonth && c.isSelected(btn.Time),
+		InRange:  c.isInRange(btn.Time),
+		Disabled: disabled,
+		Focused:  inMonth && c.isFocusedDate(btn.Time),
+		Weekend:  c.resolvedLocale().IsWeekend(btn.Weekday()),
+		Holiday:  btn.holiday,
+	}
+}
+
+// drawCell is the default CellRenderer.
+func (s *CalendarStyle) drawCell(gtx Gtx, cell CellState) Dim {
+	c := s.Calendar
+	dayStr := fmt.Sprintf("%d", c.date(cell.Date).Day)
+	bgColor := s.CellBg
+	txtColor := s.CellFg
+	if !cell.InMonth {
+		bgColor = s.OutOfMonthBg
+		txtColor = s.OutOfMonthFg
+		if cell.InRange {
+			bgColor = s.RangeOutsideBg
+		}
+	}
+	if cell.InMonth && cell.Weekend {
+		txtColor = s.WeekendFg
+	}
+	if cell.InMonth && cell.Holiday != "" {
+		bgColor = s.HolidayBg
+		txtColor = s.HolidayFg
+	}
+	if cell.Disabled {
+		txtColor = s.DisabledFg
+	}
+	if cell.InMonth && !cell.Disabled {
+		if cell.InRange {
+			bgColor = s.RangeBg
+		}
+		if cell.Today {
+			bgColor = s.TodayBg
+			txtColor = s.TodayFg
+		}
+		if cell.Hovered {
+			bgColor = s.HoverBg
+			txtColor = s.HoverFg
+		}
+		if cell.Selected {
+			bgColor = s.SelectedBg
+			txtColor = s.SelectedFg
+		}
+	}
+	mac := op.Record(gtx.Ops)
+	d := s.CellInset.Layout(gtx, func(gtx Gtx) Dim {
+		center := layout.N
+		txtSize := s.DayTextSize
+		deco := c.dayDecoration(cell.Date)
+		d := center.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+			flex := Flex{Axis: layout.Vertical, Alignment: layout.Middle}
+			var labelWidth int
+			return flex.Layout(gtx,
+				layout.Rigid(func(gtx Gtx) Dim {
+					label := material.Label(s.Theme, txtSize, dayStr)
 					label.MaxLines = 1
 					label.Color = txtColor
 					label.Alignment = text.Middle
-					if c.maxWidth < gtx.Dp(500) {
-						label.TextSize = unit.Sp(14)
+					if s.compact(gtx) {
+						label.TextSize = s.CompactTextSize
 					}
-					return label.Layout(gtx)
-				})
-				return d
-			})
-			call := mac.Stop()
-			rect := clip.Rect{Max: d.Size}
-			paint.FillShape(gtx.Ops, bgColor, rect.Op())
-			call.Add(gtx.Ops)
-			return d
+					d := label.Layout(gtx)
+					labelWidth = d.Size.X
+					return d
+				}),
+				layout.Rigid(func(gtx Gtx) Dim {
+					return c.drawUnderline(gtx, deco, labelWidth)
+				}),
+				layout.Rigid(layout.Spacer{Height: decorationSpacing}.Layout),
+				layout.Rigid(func(gtx Gtx) Dim {
+					return s.drawDecorationMarkers(gtx, deco)
+				}),
+			)
 		})
+		return d
 	})
+	call := mac.Stop()
+	rect := clip.Rect{Max: d.Size}
+	paint.FillShape(gtx.Ops, bgColor, rect.Op())
+	call.Add(gtx.Ops)
+	if cell.Focused {
+		focusRing := widget.Border{Color: s.FocusRing, Width: unit.Dp(2)}
+		focusRing.Layout(gtx, func(gtx Gtx) Dim {
+			return Dim{Size: d.Size}
+		})
+	}
+	return d
 }
 
-func (c *Calendar) drawBodyRows(gtx Gtx) Dim {
+func (s *CalendarStyle) drawBodyRows(gtx Gtx, view *monthView) Dim {
+	c := s.Calendar
 	flex := Flex{Axis: layout.Vertical}
-	t := c.Time()
-	columnWidth := c.maxWidth / 7
-	startDate := firstDayOfWeek(t, time.Monday)
-	lastDate := lastDayOfWeek(t, time.Monday)
-	day := startDate
-	endDate := lastDate
-
-	allRows := make([]FlexChild, 0)
-	cellItemsArr := make([]*cellItem, 0)
-	for rowIndex, cellIndex := 0, 0; day.Before(endDate) || rowIndex < 6; rowIndex++ {
-		var shouldBreak bool
-		for i := 0; i < 7; i++ {
-			// Minimum row cellIndex is 3 (ie at least 4 rows) and max row cellIndex is 5 (ie at least 6 rows)
-			if (rowIndex == 4 || rowIndex == 5) && i == 0 {
-				if day.Month() != c.Time().Month() {
-					allRows = allRows[:rowIndex]
-					shouldBreak = true
-					break
-				}
-			}
-			cellItemsArr = append(cellItemsArr, c.cellItemsArr[cellIndex])
-			cellItemsArr[cellIndex].Time = day
-			cellIndex++
-			day = day.AddDate(0, 0, 1)
-			if i == 0 {
-				allRows = append(allRows, FlexChild{})
-			}
-		}
-		if shouldBreak {
-			break
-		}
-	}
+	allRows := make([]FlexChild, view.rows)
+	cellItemsArr := view.cellItemsArr[:view.rows*7]
 	cellIndex := 0
 	for rowIndex := range allRows {
+		row, rowStart := rowIndex, cellItemsArr[cellIndex].Time
 		var flexChildren []FlexChild
+		if c.ShowWeekNumbers {
+			flexChildren = append(flexChildren, s.drawWeekNumberColumn(gtx, cellItemsArr[cellIndex].Time, &view.weekButtons[rowIndex]))
+		}
 		for i := 0; i < 7; i++ {
-			flexChildren = append(flexChildren, c.drawColumn(gtx, columnWidth, cellItemsArr[cellIndex]))
+			flexChildren = append(flexChildren, s.drawColumn(gtx, cellItemsArr[cellIndex]))
 			cellIndex++
 		}
 		flexChild := layout.Rigid(func(gtx Gtx) Dim {
 			flex := Flex{}
-			gtx.Constraints.Min.Y, gtx.Constraints.Max.Y = columnWidth, columnWidth
-			return flex.Layout(gtx, flexChildren...)
+			gtx.Constraints.Min.Y, gtx.Constraints.Max.Y = c.cellSize.Y, c.cellSize.Y
+			d := flex.Layout(gtx, flexChildren...)
+			s.drawRowEvents(gtx, view, row, rowStart)
+			return d
 		})
 		allRows[rowIndex] = flexChild
 	}
-	c.viewList.Axis = layout.Vertical
-	d := c.viewList.Layout(gtx, 1, func(gtx Gtx, index int) Dim {
-		return flex.Layout(gtx, allRows...)
-	})
-	return d
+	return flex.Layout(gtx, allRows...)
 }
 
 func (c *Calendar) OnMonthButtonClick(gtx Gtx, month *monthButton) {
-	t := c.Time()
-	t = time.Date(t.Year(), month.Month, t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
-	c.SetTime(t)
+	date := c.date(c.Time())
+	date.Month = month.Month
+	c.setDate(date)
 	op.InvalidateOp{}.Add(gtx.Ops)
 }
 
 func (c *Calendar) OnYearButtonClick(gtx Gtx, year *yearButton) {
-	t := c.Time()
-	t = time.Date(year.Year, t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
-	c.SetTime(t)
+	date := c.date(c.Time())
+	date.Year = year.Year
+	c.setDate(date)
 	op.InvalidateOp{}.Add(gtx.Ops)
 }
 
-func (c *Calendar) drawMonthsDropdownItems(gtx Gtx) Dim {
-	gtx.Constraints.Max.Y = (c.maxWidth / 7) * 4
+// setDate displays date, clamping its day within its month and keeping the time of the day.
+func (c *Calendar) setDate(date CalendarDate) {
+	t := c.Time()
+	c.SetTime(c.clampTime(withClock(c.system().ToTime(clampDate(c.system(), date), t.Location()), t)))
+}
+
+// NextMonth displays the month after the current one.
+func (c *Calendar) NextMonth() {
+	c.SetTime(c.clampTime(addMonths(c.system(), c.Time(), 1)))
+}
+
+// PrevMonth displays the month before the current one.
+func (c *Calendar) PrevMonth() {
+	c.SetTime(c.clampTime(addMonths(c.system(), c.Time(), -1)))
+}
+
+// NextYear displays the same month of the next year.
+func (c *Calendar) NextYear() {
+	c.SetTime(c.clampTime(addYears(c.system(), c.Time(), 1)))
+}
+
+// PrevYear displays the same month of the previous year.
+func (c *Calendar) PrevYear() {
+	c.SetTime(c.clampTime(addYears(c.system(), c.Time(), -1)))
+}
+
+func (s *CalendarStyle) drawMonthsDropdownItems(gtx Gtx) Dim {
+	c := s.Calendar
+	gtx.Constraints.Max.Y = (c.gridWidth / 7) * 4
 	op.Offset(image.Point{
 		X: gtx.Dp(16),
-		Y: gtx.Dp(viewHeaderHeight) + gtx.Dp(8),
+		Y: gtx.Dp(s.ViewHeaderHeight) + gtx.Dp(8),
 	}).Add(gtx.Ops)
 	layout.Stack{}.Layout(gtx,
 		layout.Stacked(func(gtx Gtx) Dim {
 			mac := op.Record(gtx.Ops)
-			gtx.Constraints.Min.X = gtx.Dp(dropdownWidth)
+			gtx.Constraints.Min.X = gtx.Dp(s.DropdownWidth)
 			c.monthsList.Axis = layout.Vertical
 			border := widget.Border{
-				Color:        c.Theme.ContrastBg,
+				Color:        s.DropdownBorder,
 				CornerRadius: 0,
 				Width:        unit.Dp(1),
 			}
 			d := border.Layout(gtx, func(gtx Gtx) Dim {
-				d := c.monthsList.Layout(gtx, len(allMonthsButtonsArr), func(gtx Gtx, index int) Dim {
-					bgColor := c.Theme.Bg
-					txtColor := c.Theme.Fg
-					isSelected := c.Time().Month().String() == allMonthsButtonsArr[index].Month.String()
-					if allMonthsButtonsArr[index].Hovered() || isSelected {
-						bgColor = c.Theme.Fg
-						txtColor = c.Theme.Bg
+				monthsButtons := c.monthButtons()
+				d := c.monthsList.Layout(gtx, len(monthsButtons), func(gtx Gtx, index int) Dim {
+					bgColor := s.DropdownBg
+					txtColor := s.DropdownFg
+					date := c.date(c.Time())
+					isSelected := date.Month == monthsButtons[index].Month
+					date.Month, date.Day = monthsButtons[index].Month, 1
+					isDisabled := c.isMonthDisabled(c.system().ToTime(date, c.Time().Location()))
+					if isDisabled {
+						gtx = gtx.Disabled()
+						txtColor = s.DisabledFg
+					}
+					if (monthsButtons[index].Hovered() && !isDisabled) || isSelected {
+						bgColor = s.DropdownSelectedBg
+						txtColor = s.DropdownSelectedFg
 					}
-					if allMonthsButtonsArr[index].Clicked() {
+					if monthsButtons[index].Clicked() && !isDisabled {
 						c.ShowMonthsDropdown = false
-						c.OnMonthButtonClick(gtx, &allMonthsButtonsArr[index])
+						c.OnMonthButtonClick(gtx, &monthsButtons[index])
 					}
 					mac := op.Record(gtx.Ops)
-					d := allMonthsButtonsArr[index].Layout(gtx, func(gtx Gtx) Dim {
+					d := monthsButtons[index].Layout(gtx, func(gtx Gtx) Dim {
 						inset := Inset{Top: 8, Bottom: 8, Left: 16, Right: 16}
 						return inset.Layout(gtx, func(gtx Gtx) Dim {
-							txt := allMonthsButtonsArr[index].Month.String()
-							label := material.Label(c.Theme, c.Theme.TextSize, txt)
+							txt := c.system().MonthName(monthsButtons[index].Month, c.resolvedLocale())
+							label := material.Label(s.Theme, s.TextSize, txt)
 							label.Alignment = text.Start
 							label.Color = txtColor
 							return label.Layout(gtx)
@@ -357,7 +835,7 @@ func (c *Calendar) drawMonthsDropdownItems(gtx Gtx) Dim {
 			})
 			call := mac.Stop()
 			rect := clip.Rect{Max: d.Size}
-			paint.FillShape(gtx.Ops, c.Theme.Bg, rect.Op())
+			paint.FillShape(gtx.Ops, s.DropdownBg, rect.Op())
 			call.Add(gtx.Ops)
 			return d
 		}),
@@ -365,37 +843,39 @@ func (c *Calendar) drawMonthsDropdownItems(gtx Gtx) Dim {
 	return Dim{}
 }
 
-func (c *Calendar) drawYearsDropdownItems(gtx Gtx) Dim {
-	gtx.Constraints.Max.Y = (c.maxWidth / 7) * 4
+func (s *CalendarStyle) drawYearsDropdownItems(gtx Gtx) Dim {
+	c := s.Calendar
+	gtx.Constraints.Max.Y = (c.gridWidth / 7) * 4
 	op.Offset(image.Point{
-		X: gtx.Dp(16) + gtx.Dp(dropdownWidth) + gtx.Dp(spaceBetweenHeaderDropdowns),
-		Y: gtx.Dp(viewHeaderHeight) + gtx.Dp(8),
+		X: gtx.Dp(16) + gtx.Dp(s.DropdownWidth) + gtx.Dp(s.HeaderSpacing),
+		Y: gtx.Dp(s.ViewHeaderHeight) + gtx.Dp(8),
 	}).Add(gtx.Ops)
 	layout.Stack{}.Layout(gtx,
 		layout.Stacked(func(gtx Gtx) Dim {
 			mac := op.Record(gtx.Ops)
-			gtx.Constraints.Min.X = gtx.Dp(dropdownWidth)
+			gtx.Constraints.Min.X = gtx.Dp(s.DropdownWidth)
 			c.yearsList.Axis = layout.Vertical
-			border := widget.Border{Color: c.Theme.ContrastBg, CornerRadius: 0, Width: unit.Dp(1)}
+			border := widget.Border{Color: s.DropdownBorder, CornerRadius: 0, Width: unit.Dp(1)}
 			d := border.Layout(gtx, func(gtx Gtx) Dim {
-				d := c.yearsList.Layout(gtx, len(allYearsButtonsSlice), func(gtx Gtx, index int) Dim {
-					bgColor := c.Theme.Bg
-					txtColor := c.Theme.Fg
-					isSelected := c.Time().Year() == allYearsButtonsSlice[index].Year
-					if allYearsButtonsSlice[index].Hovered() || isSelected {
-						bgColor = c.Theme.Fg
-						txtColor = c.Theme.Bg
+				yearsButtons := c.yearsButtons()
+				d := c.yearsList.Layout(gtx, len(yearsButtons), func(gtx Gtx, index int) Dim {
+					bgColor := s.DropdownBg
+					txtColor := s.DropdownFg
+					isSelected := c.date(c.Time()).Year == yearsButtons[index].Year
+					if yearsButtons[index].Hovered() || isSelected {
+						bgColor = s.DropdownSelectedBg
+						txtColor = s.DropdownSelectedFg
 					}
-					if allYearsButtonsSlice[index].Clicked() {
+					if yearsButtons[index].Clicked() {
 						c.showYearsDropdown = false
-						c.OnYearButtonClick(gtx, &allYearsButtonsSlice[index])
+						c.OnYearButtonClick(gtx, &yearsButtons[index])
 					}
 					mac := op.Record(gtx.Ops)
-					d := allYearsButtonsSlice[index].Layout(gtx, func(gtx Gtx) Dim {
+					d := yearsButtons[index].Layout(gtx, func(gtx Gtx) Dim {
 						inset := Inset{Top: 8, Bottom: 8, Left: 16, Right: 16}
 						return inset.Layout(gtx, func(gtx Gtx) Dim {
-							txt := fmt.Sprintf("%d", allYearsButtonsSlice[index].Year)
-							label := material.Label(c.Theme, c.Theme.TextSize, txt)
+							txt := fmt.Sprintf("%d", yearsButtons[index].Year)
+							label := material.Label(s.Theme, s.TextSize, txt)
 							label.Alignment = text.Start
 							label.Color = txtColor
 							return label.Layout(gtx)
@@ -411,7 +891,7 @@ func (c *Calendar) drawYearsDropdownItems(gtx Gtx) Dim {
 			})
 			call := mac.Stop()
 			rect := clip.Rect{Max: d.Size}
-			paint.FillShape(gtx.Ops, c.Theme.Bg, rect.Op())
+			paint.FillShape(gtx.Ops, s.DropdownBg, rect.Op())
 			call.Add(gtx.Ops)
 			return d
 		}),
@@ -419,19 +899,24 @@ func (c *Calendar) drawYearsDropdownItems(gtx Gtx) Dim {
 	return Dim{}
 }
 
-func (c *Calendar) drawViewHeader(gtx Gtx) Dim {
-	month := c.Time().Month().String()
-	year := fmt.Sprintf("%d", c.Time().Year())
-	gtx.Constraints.Max.Y, gtx.Constraints.Min.Y = gtx.Dp(viewHeaderHeight), gtx.Dp(viewHeaderHeight)
+func (s *CalendarStyle) drawViewHeader(gtx Gtx) Dim {
+	c := s.Calendar
+	date := c.date(c.Time())
+	month := c.system().MonthName(date.Month, c.resolvedLocale())
+	year := fmt.Sprintf("%d", date.Year)
+	gtx.Constraints.Max.Y, gtx.Constraints.Min.Y = gtx.Dp(s.ViewHeaderHeight), gtx.Dp(s.ViewHeaderHeight)
 	flex := Flex{Spacing: layout.SpaceEnd, Alignment: layout.Middle}
 	d := flex.Layout(gtx,
 		layout.Rigid(func(gtx Gtx) Dim {
+			if c.ZoomableTitle {
+				return s.drawZoomTitle(gtx)
+			}
 			if c.btnDropdownMonth.Clicked() {
 				c.ShowMonthsDropdown = !c.ShowMonthsDropdown
 				c.showYearsDropdown = false
 				if c.ShowMonthsDropdown {
-					for i, eachButton := range allMonthsButtonsArr {
-						if eachButton.Month.String() == c.Time().Month().String() {
+					for i, eachButton := range c.monthButtons() {
+						if eachButton.Month == date.Month {
 							c.monthsList.Position.First = i
 							c.monthsList.Position.Offset = -32
 							break
@@ -439,24 +924,34 @@ func (c *Calendar) drawViewHeader(gtx Gtx) Dim {
 					}
 				}
 			}
-			gtx.Constraints.Min.X = gtx.Dp(dropdownWidth)
+			gtx.Constraints.Min.X = gtx.Dp(s.DropdownWidth)
 			d := c.btnDropdownMonth.Layout(gtx, func(gtx Gtx) Dim {
 				flex := Flex{Spacing: layout.SpaceBetween}
 				return flex.Layout(gtx,
 					layout.Rigid(func(gtx Gtx) Dim {
-						return material.Label(c.Theme, c.Theme.TextSize, month).Layout(gtx)
+						label := material.Label(s.Theme, s.TextSize, month)
+						label.Color = s.TitleFg
+						return label.Layout(gtx)
 					}),
 					layout.Rigid(layout.Spacer{Width: unit.Dp(16)}.Layout),
 					layout.Rigid(func(gtx Gtx) Dim {
 						downIcon, _ := widget.NewIcon(icons.NavigationArrowDropDown)
-						return downIcon.Layout(gtx, c.Theme.ContrastBg)
+						return downIcon.Layout(gtx, s.IconColor)
 					}),
 				)
 			})
 			return d
 		}),
-		layout.Rigid(layout.Spacer{Width: spaceBetweenHeaderDropdowns}.Layout),
 		layout.Rigid(func(gtx Gtx) Dim {
+			if c.ZoomableTitle {
+				return Dim{}
+			}
+			return layout.Spacer{Width: s.HeaderSpacing}.Layout(gtx)
+		}),
+		layout.Rigid(func(gtx Gtx) Dim {
+			if c.ZoomableTitle {
+				return Dim{}
+			}
 			if c.btnDropdownYear.Clicked() {
 				c.ShowMonthsDropdown = false
 				c.showYearsDropdown = !c.showYearsDropdown
@@ -465,29 +960,86 @@ func (c *Calendar) drawViewHeader(gtx Gtx) Dim {
 				}
 			}
 			d := c.btnDropdownYear.Layout(gtx, func(gtx Gtx) Dim {
-				gtx.Constraints.Min.X = gtx.Dp(dropdownWidth)
+				gtx.Constraints.Min.X = gtx.Dp(s.DropdownWidth)
 				flex := Flex{Spacing: layout.SpaceBetween}
 				return flex.Layout(gtx,
 					layout.Rigid(func(gtx Gtx) Dim {
-						label := material.Label(c.Theme, c.Theme.TextSize, year)
+						label := material.Label(s.Theme, s.TextSize, year)
+						label.Color = s.TitleFg
 						return label.Layout(gtx)
 					}),
 					layout.Rigid(layout.Spacer{Width: unit.Dp(16)}.Layout),
 					layout.Rigid(func(gtx Gtx) Dim {
 						downIcon, _ := widget.NewIcon(icons.NavigationArrowDropDown)
-						return downIcon.Layout(gtx, c.Theme.ContrastBg)
+						return downIcon.Layout(gtx, s.IconColor)
 					}),
 				)
 			})
 			return d
 		}),
+		layout.Flexed(1, func(gtx Gtx) Dim {
+			return Dim{Size: image.Point{X: gtx.Constraints.Min.X}}
+		}),
+		layout.Rigid(func(gtx Gtx) Dim {
+			if !c.ShowYearNavigation || c.View != CalendarMonthView {
+				return Dim{}
+			}
+			if c.btnPrevYear.Clicked() {
+				c.PrevYear()
+			}
+			return s.drawNavigationButton(gtx, &c.btnPrevYear, icons.NavigationChevronLeft, true, c.isMonthDisabled(addYears(c.system(), c.Time(), -1)))
+		}),
+		layout.Rigid(func(gtx Gtx) Dim {
+			if c.btnPrevMonth.Clicked() {
+				c.step(-1)
+			}
+			return s.drawNavigationButton(gtx, &c.btnPrevMonth, icons.NavigationChevronLeft, false, c.isStepDisabled(-1))
+		}),
+		layout.Rigid(func(gtx Gtx) Dim {
+			if c.btnNextMonth.Clicked() {
+				c.step(1)
+			}
+			return s.drawNavigationButton(gtx, &c.btnNextMonth, icons.NavigationChevronRight, false, c.isStepDisabled(1))
+		}),
+		layout.Rigid(func(gtx Gtx) Dim {
+			if !c.ShowYearNavigation || c.View != CalendarMonthView {
+				return Dim{}
+			}
+			if c.btnNextYear.Clicked() {
+				c.NextYear()
+			}
+			return s.drawNavigationButton(gtx, &c.btnNextYear, icons.NavigationChevronRight, true, c.isMonthDisabled(addYears(c.system(), c.Time(), 1)))
+		}),
 	)
 	return d
 }
 
+// drawNavigationButton draws a chevron button of the header, doubling the chevron when double is set.
+func (s *CalendarStyle) drawNavigationButton(gtx Gtx, btn *widget.Clickable, iconData []byte, double, disabled bool) Dim {
+	iconColor := s.IconColor
+	if disabled {
+		gtx = gtx.Disabled()
+		iconColor = s.DisabledIconColor
+	}
+	size := gtx.Dp(s.ViewHeaderHeight)
+	return btn.Layout(gtx, func(gtx Gtx) Dim {
+		gtx.Constraints.Min = image.Point{X: size, Y: size}
+		gtx.Constraints.Max = gtx.Constraints.Min
+		icon, _ := widget.NewIcon(iconData)
+		if !double {
+			return icon.Layout(gtx, iconColor)
+		}
+		// the second chevron overlaps the right half of the first one
+		icon.Layout(gtx, iconColor)
+		defer op.Offset(image.Point{X: size / 2}).Push(gtx.Ops).Pop()
+		icon.Layout(gtx, iconColor)
+		return Dim{Size: image.Point{X: size * 3 / 2, Y: size}}
+	})
+}
+
 func (c *Calendar) scrollToSelectedYear() {
-	for i, eachYear := range allYearsButtonsSlice {
-		if eachYear.Year == c.Time().Year() {
+	for i, eachYear := range c.yearsButtons() {
+		if eachYear.Year == c.date(c.Time()).Year {
 			c.yearsList.Position.First = i
 			c.yearsList.Position.Offset = -32
 			break
@@ -0,0 +1,50 @@
+package giowidgets
+
+import (
+	"gioui.org/op"
+	"time"
+)
+
+// Clock tells the Calendar the current time, it decides which day is today.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts an ordinary function to the Clock interface.
+type ClockFunc func() time.Time
+
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// SystemClock is the Clock reading the time of the system, used when Calendar.Clock is nil.
+var SystemClock Clock = ClockFunc(time.Now)
+
+// location returns the time zone of the Calendar, defaulting to the local time zone.
+func (c *Calendar) location() *time.Location {
+	if c.Location == nil {
+		return time.Local
+	}
+	return c.Location
+}
+
+// now returns the current time of the Clock in the time zone of the Calendar.
+func (c *Calendar) now() time.Time {
+	clock := c.Clock
+	if clock == nil {
+		clock = SystemClock
+	}
+	return clock.Now().In(c.location())
+}
+
+// Today returns the midnight starting the current day in the time zone of the Calendar.
+func (c *Calendar) Today() time.Time {
+	year, month, day := c.now().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, c.location())
+}
+
+// invalidateAtMidnight schedules a redraw at the start of the next day, moving the today highlight.
+func (c *Calendar) invalidateAtMidnight(gtx Gtx) {
+	untilMidnight := c.Today().AddDate(0, 0, 1).Sub(c.now())
+	op.InvalidateOp{At: gtx.Now.Add(untilMidnight)}.Add(gtx.Ops)
+}
@@ -0,0 +1,122 @@
+package giowidgets
+
+import (
+	"fmt"
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/text"
+	"gioui.org/unit"
+	"gioui.org/widget/material"
+	"image"
+	"image/color"
+	"time"
+)
+
+// DayDecoration describes the markers drawn under the day number of a Calendar cell.
+type DayDecoration struct {
+	// Dots draws a small circle for each color.
+	Dots []color.NRGBA
+	// Badge draws a count in a pill, it is hidden when zero.
+	Badge int
+	// BadgeColor is the background of the badge, the theme contrast background is used when it is transparent.
+	BadgeColor color.NRGBA
+	// Underline draws a line under the day number, it is hidden when transparent.
+	Underline color.NRGBA
+}
+
+// DayDecorator is asked by the Calendar for the decorations of every day it draws.
+type DayDecorator interface {
+	Decorate(day time.Time) DayDecoration
+}
+
+// DayDecoratorFunc adapts an ordinary function to the DayDecorator interface.
+type DayDecoratorFunc func(day time.Time) DayDecoration
+
+func (f DayDecoratorFunc) Decorate(day time.Time) DayDecoration {
+	return f(day)
+}
+
+const (
+	decorationDotSize     = unit.Dp(6)
+	decorationSpacing     = unit.Dp(2)
+	decorationUnderline   = unit.Dp(2)
+	decorationBadgeHeight = unit.Dp(14)
+)
+
+// dayDecoration returns the decoration of day, or the zero DayDecoration when there is no DayDecorator.
+func (c *Calendar) dayDecoration(day time.Time) DayDecoration {
+	if c.DayDecorator == nil {
+		return DayDecoration{}
+	}
+	return c.DayDecorator.Decorate(day)
+}
+
+// drawUnderline draws the underline of deco below a widget of the given width.
+func (c *Calendar) drawUnderline(gtx Gtx, deco DayDecoration, width int) Dim {
+	if deco.Underline.A == 0 {
+		return Dim{}
+	}
+	size := image.Point{X: width, Y: gtx.Dp(decorationUnderline)}
+	paint.FillShape(gtx.Ops, deco.Underline, clip.Rect{Max: size}.Op())
+	return Dim{Size: size}
+}
+
+// drawDecorationMarkers draws the dots and the badge of deco in a single row.
+func (s *CalendarStyle) drawDecorationMarkers(gtx Gtx, deco DayDecoration) Dim {
+	if len(deco.Dots) == 0 && deco.Badge == 0 {
+		return Dim{}
+	}
+	gtx.Constraints.Min = image.Point{}
+	children := make([]FlexChild, 0, len(deco.Dots)*2+2)
+	for _, dotColor := range deco.Dots {
+		dotColor := dotColor
+		children = append(children,
+			layout.Rigid(layout.Spacer{Width: decorationSpacing}.Layout),
+			layout.Rigid(func(gtx Gtx) Dim {
+				size := gtx.Dp(decorationDotSize)
+				rect := image.Rectangle{Max: image.Point{X: size, Y: size}}
+				paint.FillShape(gtx.Ops, dotColor, clip.Ellipse(rect).Op(gtx.Ops))
+				return Dim{Size: rect.Max}
+			}),
+		)
+	}
+	if deco.Badge != 0 {
+		children = append(children,
+			layout.Rigid(layout.Spacer{Width: decorationSpacing}.Layout),
+			layout.Rigid(func(gtx Gtx) Dim {
+				return s.drawBadge(gtx, deco)
+			}),
+		)
+	}
+	// the first spacer is dropped to keep the markers centered
+	flex := Flex{Alignment: layout.Middle}
+	return flex.Layout(gtx, children[1:]...)
+}
+
+func (s *CalendarStyle) drawBadge(gtx Gtx, deco DayDecoration) Dim {
+	bgColor := deco.BadgeColor
+	if bgColor.A == 0 {
+		bgColor = s.BadgeBg
+	}
+	height := gtx.Dp(decorationBadgeHeight)
+	gtx.Constraints.Min = image.Point{X: height, Y: height}
+	gtx.Constraints.Max.Y = height
+	return layout.Stack{Alignment: layout.Center}.Layout(gtx,
+		layout.Expanded(func(gtx Gtx) Dim {
+			rect := image.Rectangle{Max: gtx.Constraints.Min}
+			paint.FillShape(gtx.Ops, bgColor, clip.UniformRRect(rect, height/2).Op(gtx.Ops))
+			return Dim{Size: rect.Max}
+		}),
+		layout.Stacked(func(gtx Gtx) Dim {
+			inset := Inset{Left: 4, Right: 4}
+			return inset.Layout(gtx, func(gtx Gtx) Dim {
+				label := material.Label(s.Theme, s.BadgeTextSize, fmt.Sprintf("%d", deco.Badge))
+				label.Color = s.BadgeFg
+				label.MaxLines = 1
+				label.Alignment = text.Middle
+				return label.Layout(gtx)
+			})
+		}),
+	)
+}
@@ -0,0 +1,177 @@
+package giowidgets
+
+import (
+	"gioui.org/f32"
+	"gioui.org/gesture"
+	"gioui.org/io/pointer"
+	"gioui.org/op"
+	"gioui.org/widget"
+	"time"
+)
+
+// OnEventMoved is called when an event is dropped on another day or time slot with the start it
+// would have there, returning false vetoes the move.
+type OnEventMoved func(event Event, newStart time.Time) bool
+
+// EventMover is implemented by the EventSources able to reschedule their events. The Calendar moves
+// the dropped events which were not vetoed by OnEventMoved, set a *EventList as the EventSource
+// to have it moved.
+type EventMover interface {
+	MoveEvent(event Event, newStart time.Time)
+}
+
+// MoveEvent moves event, as returned by Events, to newStart keeping its duration. An occurrence of
+// a recurring event is excluded from its Recurrence and added as a single event, it is found by the
+// ID of the recurring event and isn't moved when that ID is empty.
+func (l *EventList) MoveEvent(event Event, newStart time.Time) {
+	for i, e := range *l {
+		if e == event {
+			(*l)[i] = e.movedTo(newStart)
+			return
+		}
+	}
+	if event.ID == "" {
+		return
+	}
+	for _, e := range *l {
+		if e.Recurrence != nil && e.ID == event.ID {
+			e.Recurrence.ExDates = append(e.Recurrence.ExDates, event.Start)
+			*l = append(*l, event.movedTo(newStart))
+			return
+		}
+	}
+}
+
+// movedTo returns e starting at start, with the same duration or, for an all-day event, spanning
+// the same number of days.
+func (e Event) movedTo(start time.Time) Event {
+	if e.AllDay {
+		e.End = start.AddDate(0, 0, e.spannedDays())
+	} else {
+		e.End = start.Add(e.End.Sub(e.Start))
+	}
+	e.Start = start
+	return e
+}
+
+// eventChip is the bar of an event, it is clicked and dragged.
+type eventChip struct {
+	widget.Clickable
+	drag gesture.Drag
+}
+
+// eventKey identifies the bar of an event across the frames, by the ID and the start of the
+// occurrence and n, counting the bars having the same ID and start in a frame.
+type eventKey struct {
+	id    string
+	start int64
+	n     int
+}
+
+// eventChips keeps the bars of the events drawn by a view across the frames.
+type eventChips struct {
+	drawn, previous map[eventKey]*eventChip
+}
+
+// frame starts a frame, the bars not drawn in the previous frame are dropped.
+func (cs *eventChips) frame() {
+	cs.previous, cs.drawn = cs.drawn, cs.previous
+	if cs.drawn == nil {
+		cs.drawn = map[eventKey]*eventChip{}
+	}
+	for key := range cs.drawn {
+		delete(cs.drawn, key)
+	}
+}
+
+// chip returns the bar of event and its key, the bar it had in the previous frame if any.
+func (cs *eventChips) chip(event Event) (*eventChip, eventKey) {
+	key := eventKey{id: event.ID, start: event.Start.UnixNano()}
+	for cs.drawn[key] != nil {
+		key.n++
+	}
+	chip := cs.previous[key]
+	if chip == nil {
+		chip = &eventChip{}
+	}
+	cs.drawn[key] = chip
+	return chip, key
+}
+
+// eventTarget returns the start of a dragged event for the pointer at pos, pressed at press, both
+// in the coordinates of its bar.
+type eventTarget func(press, pos f32.Point) time.Time
+
+// eventDrag is the event being dragged by the bar of key toward target.
+type eventDrag struct {
+	key    eventKey
+	active bool
+	event  Event
+	press  f32.Point
+	target time.Time
+}
+
+// drags reports whether the bar of key is dragged.
+func (d eventDrag) drags(key eventKey) bool {
+	return d.active && d.key == key
+}
+
+// movesEvents reports whether the events can be dragged.
+func (c *Calendar) movesEvents() bool {
+	_, ok := c.EventSource.(EventMover)
+	return ok || c.OnEventMoved != nil
+}
+
+// dragEvent follows the drag of the bar of event by chip having key, target gives the start of the
+// event where the pointer is.
+func (c *Calendar) dragEvent(gtx Gtx, chip *eventChip, key eventKey, event Event, target eventTarget) {
+	for _, e := range chip.drag.Events(gtx.Metric, gtx, gesture.Both) {
+		switch e.Type {
+		case pointer.Press:
+			c.eventDrag = eventDrag{key: key, active: true, event: event, press: e.Position, target: event.Start}
+		case pointer.Drag:
+			if !c.eventDrag.drags(key) {
+				continue
+			}
+			newStart := target(c.eventDrag.press, e.Position)
+			if c.isDisabled(newStart) {
+				newStart = c.eventDrag.event.Start
+			}
+			c.eventDrag.target = newStart
+			op.InvalidateOp{}.Add(gtx.Ops)
+		case pointer.Release:
+			if c.eventDrag.drags(key) {
+				c.dropEvent()
+			}
+		case pointer.Cancel:
+			if c.eventDrag.drags(key) {
+				c.eventDrag = eventDrag{}
+			}
+		}
+	}
+}
+
+// movedEvent returns the dragged event at its drop target, ok is false when no event is dragged
+// away from its start.
+func (c *Calendar) movedEvent() (moved Event, ok bool) {
+	d := c.eventDrag
+	if !d.active || d.target.Equal(d.event.Start) {
+		return moved, false
+	}
+	return d.event.movedTo(d.target), true
+}
+
+// dropEvent ends the drag, moving the event unless OnEventMoved vetoes it.
+func (c *Calendar) dropEvent() {
+	d := c.eventDrag
+	c.eventDrag = eventDrag{}
+	if d.target.Equal(d.event.Start) {
+		return
+	}
+	if c.OnEventMoved != nil && !c.OnEventMoved(d.event, d.target) {
+		return
+	}
+	if mover, ok := c.EventSource.(EventMover); ok {
+		mover.MoveEvent(d.event, d.target)
+	}
+}
@@ -0,0 +1,68 @@
+package giowidgets
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMoveEvent(t *testing.T) {
+	start := time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC)
+	recurrence, err := ParseRRule("FREQ=DAILY;COUNT=3", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	list := EventList{
+		{Title: "Review", Start: start, End: start.Add(time.Hour)},
+		{Title: "Lunch", Start: start, End: start.Add(2 * time.Hour)},
+		{ID: "standup", Title: "Standup", Start: start, End: start.Add(15 * time.Minute), Recurrence: &recurrence},
+	}
+	newStart := start.Add(3 * time.Hour)
+	// the events without an ID starting at the same time are told apart
+	list.MoveEvent(list.Events(start, start.AddDate(0, 0, 1))[1], newStart)
+	if !list[0].Start.Equal(start) || !list[1].Start.Equal(newStart) || !list[1].End.Equal(newStart.Add(2*time.Hour)) {
+		t.Errorf("Review at %v, Lunch from %v to %v, want Lunch moved to %v", list[0].Start, list[1].Start, list[1].End, newStart)
+	}
+	occurrence := list[2].Occurrences(start.AddDate(0, 0, 1), start.AddDate(0, 0, 2))[0]
+	list.MoveEvent(occurrence, newStart.AddDate(0, 0, 1))
+	if len(list) != 4 || !list[3].Start.Equal(newStart.AddDate(0, 0, 1)) {
+		t.Fatalf("got %v, want the occurrence moved as a single event", list)
+	}
+	if got := list[2].Occurrences(start, start.AddDate(0, 0, 3)); len(got) != 2 {
+		t.Errorf("got %d occurrences left, want 2", len(got))
+	}
+	// an occurrence can't be traced back to a recurring event without an ID
+	list[2].ID = ""
+	occurrence.ID = ""
+	list.MoveEvent(occurrence, newStart)
+	if len(list) != 4 {
+		t.Errorf("moved an occurrence without an ID: %v", list)
+	}
+}
+
+func TestEventChips(t *testing.T) {
+	start := time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC)
+	review := Event{Title: "Review", Start: start}
+	lunch := Event{Title: "Lunch", Start: start}
+	standup := Event{ID: "standup", Start: start}
+	var chips eventChips
+	chips.frame()
+	reviewChip, reviewKey := chips.chip(review)
+	lunchChip, lunchKey := chips.chip(lunch)
+	standupChip, _ := chips.chip(standup)
+	if reviewKey == lunchKey || reviewChip == lunchChip || reviewChip == standupChip {
+		t.Fatal("two events share a chip")
+	}
+	// the events are drawn in another order in the next frame
+	chips.frame()
+	if chip, _ := chips.chip(standup); chip != standupChip {
+		t.Error("standup got another chip")
+	}
+	if chip, key := chips.chip(review); chip != reviewChip || key != reviewKey {
+		t.Error("review got another chip")
+	}
+	chips.frame()
+	chips.frame()
+	if chip, _ := chips.chip(standup); chip == standupChip {
+		t.Error("kept the chip of an event not drawn in the previous frame")
+	}
+}
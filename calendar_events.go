@@ -0,0 +1,333 @@
+package giowidgets
+
+import (
+	"fmt"
+	"gioui.org/f32"
+	"gioui.org/io/pointer"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/text"
+	"gioui.org/unit"
+	"gioui.org/widget/material"
+	"image"
+	"image/color"
+	"sort"
+	"time"
+)
+
+// Event is an entry displayed by the Calendar.
+type Event struct {
+	ID    string
+	Title string
+	// Start and End bound the event, End is exclusive. All-day events span the days from the day
+	// of Start up to, but not including, the day of End.
+	Start  time.Time
+	End    time.Time
+	AllDay bool
+	// Color fills the bar of the event, the style EventBg is used when it is transparent.
+	Color color.NRGBA
+	// Recurrence repeats the event, it is nil for a single event.
+	Recurrence *Recurrence
+}
+
+// EventSource is asked by the Calendar for the events overlapping the displayed days,
+// from start up to but not including end. It is queried at every frame.
+type EventSource interface {
+	Events(start, end time.Time) []Event
+}
+
+// EventList is an EventSource holding its events in memory, the recurring ones are expanded
+// into their occurrences.
+type EventList []Event
+
+func (l EventList) Events(start, end time.Time) []Event {
+	var events []Event
+	for _, e := range l {
+		events = append(events, e.Occurrences(start, end)...)
+	}
+	return events
+}
+
+type OnEventClick func(event Event)
+
+// OnMoreEventsClick is called with the day and the events of the "+N more" chip which was clicked.
+type OnMoreEventsClick func(day time.Time, events []Event)
+
+// overlaps reports whether e overlaps the time from start up to but not including end.
+func (e Event) overlaps(start, end time.Time) bool {
+	eventEnd := e.End
+	if !eventEnd.After(e.Start) {
+		// an event without a duration still occupies its instant
+		eventEnd = e.Start.Add(time.Nanosecond)
+	}
+	return e.Start.Before(end) && eventEnd.After(start)
+}
+
+// days returns the midnights in loc of the first and the last day occupied by e.
+func (e Event) days(loc *time.Location) (first, last time.Time) {
+	midnight := func(t time.Time) time.Time {
+		if !e.AllDay {
+			t = t.In(loc)
+		}
+		// the days of an all-day event are those of its own location, whatever loc is
+		year, month, day := t.Date()
+		return time.Date(year, month, day, 0, 0, 0, 0, loc)
+	}
+	first = midnight(e.Start)
+	last = first
+	if e.End.After(e.Start) {
+		// End is exclusive, an event ending at midnight does not occupy that day
+		last = midnight(e.End.Add(-time.Nanosecond))
+	}
+	if last.Before(first) {
+		last = first
+	}
+	return first, last
+}
+
+// eventSpacing separates the lanes of events in a cell.
+const eventSpacing = unit.Dp(2)
+
+// eventSegment is the part of an event drawn in a week row of a month grid.
+type eventSegment struct {
+	event Event
+	// first and last are the columns of the row spanned by the segment
+	first, last int
+	lane        int
+}
+
+// layoutRowEvents places the events overlapping the days consecutive days, at most 7, starting on
+// rowStart into lanes, the longer events starting first are placed in the top lanes.
+func layoutRowEvents(events []Event, rowStart time.Time, days int) []eventSegment {
+	loc := rowStart.Location()
+	rowEnd := rowStart.AddDate(0, 0, days)
+	var segments []eventSegment
+	for _, e := range events {
+		first, last := e.days(loc)
+		if !first.Before(rowEnd) || last.Before(rowStart) {
+			continue
+		}
+		segment := eventSegment{event: e, first: 0, last: days - 1}
+		for column := 0; column < days; column++ {
+			day := rowStart.AddDate(0, 0, column)
+			if sameDay(day, first) {
+				segment.first = column
+			}
+			if sameDay(day, last) {
+				segment.last = column
+			}
+		}
+		segments = append(segments, segment)
+	}
+	sort.SliceStable(segments, func(i, j int) bool {
+		a, b := segments[i], segments[j]
+		if a.first != b.first {
+			return a.first < b.first
+		}
+		if a.last-a.first != b.last-b.first {
+			return a.last-a.first > b.last-b.first
+		}
+		return a.event.Start.Before(b.event.Start)
+	})
+	var lanes [][7]bool
+	for i := range segments {
+		lane := 0
+		for ; lane < len(lanes); lane++ {
+			free := true
+			for column := segments[i].first; column <= segments[i].last; column++ {
+				free = free && !lanes[lane][column]
+			}
+			if free {
+				break
+			}
+		}
+		if lane == len(lanes) {
+			lanes = append(lanes, [7]bool{})
+		}
+		for column := segments[i].first; column <= segments[i].last; column++ {
+			lanes[lane][column] = true
+		}
+		segments[i].lane = lane
+	}
+	return segments
+}
+
+// loadEvents queries the EventSource for the days of the grid of view.
+func (c *Calendar) loadEvents(view *monthView) {
+	view.events = nil
+	if c.EventSource == nil {
+		return
+	}
+	cells := view.cellItemsArr[:view.rows*7]
+	view.events = c.EventSource.Events(cells[0].Time, cells[len(cells)-1].AddDate(0, 0, 1))
+}
+
+// drawRowEvents draws the events of the week row starting on rowStart over its cells, with a
+// "+N more" chip in the cells having more events than fit.
+func (s *CalendarStyle) drawRowEvents(gtx Gtx, view *monthView, row int, rowStart time.Time) {
+	c := s.Calendar
+	view.eventChips[row].frame()
+	cellSize := c.cellSize
+	left := 0
+	if c.ShowWeekNumbers {
+		left = cellSize.X
+	}
+	if moved, ok := c.movedEvent(); ok {
+		// highlights the days the dragged event is dropped on
+		first, last := moved.days(rowStart.Location())
+		for column := 0; column < 7; column++ {
+			day := rowStart.AddDate(0, 0, column)
+			if compareDays(day, first) >= 0 && compareDays(day, last) <= 0 {
+				rect := image.Rectangle{Min: image.Point{X: left + column*cellSize.X}, Max: image.Point{X: left + (column+1)*cellSize.X, Y: cellSize.Y}}
+				paint.FillShape(gtx.Ops, s.DropTargetBg, clip.Rect(rect).Op())
+			}
+		}
+	}
+	if len(view.events) == 0 {
+		return
+	}
+	segments := layoutRowEvents(view.events, rowStart, 7)
+	if len(segments) == 0 {
+		return
+	}
+	textSize := s.DayTextSize
+	if s.compact(gtx) {
+		textSize = s.CompactTextSize
+	}
+	top := gtx.Dp(s.CellInset.Top) + gtx.Sp(textSize)*3/2
+	laneHeight := gtx.Dp(s.EventHeight) + gtx.Dp(eventSpacing)
+	lanes := (cellSize.Y - top) / laneHeight
+	if lanes < 1 {
+		return
+	}
+	var perColumn [7]int
+	for _, segment := range segments {
+		for column := segment.first; column <= segment.last; column++ {
+			perColumn[column]++
+		}
+	}
+	visible := lanes
+	for _, count := range perColumn {
+		if count > lanes {
+			visible = lanes - 1
+			break
+		}
+	}
+	for _, segment := range segments {
+		if segment.lane >= visible {
+			continue
+		}
+		chip, key := view.eventChips[row].chip(segment.event)
+		offset := image.Point{X: left + segment.first*cellSize.X, Y: top + segment.lane*laneHeight}
+		size := image.Point{X: (segment.last - segment.first + 1) * cellSize.X, Y: laneHeight - gtx.Dp(eventSpacing)}
+		event, first := segment.event, segment.first
+		target := func(press, pos f32.Point) time.Time {
+			// the days under the pointer when pressed and now, the rows being those of the grid
+			cell := func(p f32.Point) (column, row int) {
+				x, y := float32(first*cellSize.X)+p.X, float32(offset.Y)+p.Y
+				return floorDiv(x, cellSize.X), floorDiv(y, cellSize.Y)
+			}
+			pressColumn, _ := cell(press)
+			column, rowShift := cell(pos)
+			column = clampInt(column, 0, 6)
+			rowShift = clampInt(rowShift, -row, view.rows-1-row)
+			start := event.Start
+			if !event.AllDay {
+				start = start.In(rowStart.Location())
+			}
+			year, month, day := start.Date()
+			hour, minute, second := start.Clock()
+			return time.Date(year, month, day+rowShift*7+column-pressColumn, hour, minute, second, start.Nanosecond(), start.Location())
+		}
+		stack := op.Offset(offset).Push(gtx.Ops)
+		s.drawEventBar(gtx, chip, key, event, size, target)
+		stack.Pop()
+	}
+	for column := range perColumn {
+		hidden := 0
+		var dayEvents []Event
+		for _, segment := range segments {
+			if segment.first <= column && column <= segment.last {
+				dayEvents = append(dayEvents, segment.event)
+				if segment.lane >= visible {
+					hidden++
+				}
+			}
+		}
+		if hidden == 0 {
+			continue
+		}
+		day := rowStart.AddDate(0, 0, column)
+		btn := &view.moreButtons[row*7+column]
+		if btn.Clicked() && c.OnMoreEventsClick != nil {
+			c.OnMoreEventsClick(day, dayEvents)
+		}
+		offset := image.Point{X: left + column*cellSize.X, Y: top + visible*laneHeight}
+		stack := op.Offset(offset).Push(gtx.Ops)
+		gtx := gtx
+		gtx.Constraints.Min = image.Point{X: cellSize.X, Y: laneHeight - gtx.Dp(eventSpacing)}
+		gtx.Constraints.Max = gtx.Constraints.Min
+		btn.Layout(gtx, func(gtx Gtx) Dim {
+			return layout.W.Layout(gtx, func(gtx Gtx) Dim {
+				inset := Inset{Left: 4, Right: 4}
+				return inset.Layout(gtx, func(gtx Gtx) Dim {
+					label := material.Label(s.Theme, s.EventTextSize, c.resolvedLocale().MoreEventsLabel(hidden))
+					label.Color = s.MoreEventsFg
+					label.MaxLines = 1
+					return label.Layout(gtx)
+				})
+			})
+		})
+		stack.Pop()
+	}
+}
+
+// drawEventBar draws the bar of event with the given size, chip having key reports its clicks and
+// its drags toward target.
+func (s *CalendarStyle) drawEventBar(gtx Gtx, chip *eventChip, key eventKey, event Event, size image.Point, target eventTarget) Dim {
+	c := s.Calendar
+	if chip.Clicked() && c.OnEventClick != nil {
+		c.OnEventClick(event)
+	}
+	if c.movesEvents() {
+		c.dragEvent(gtx, chip, key, event, target)
+		defer clip.Rect{Max: size}.Push(gtx.Ops).Pop()
+		chip.drag.Add(gtx.Ops)
+		if c.eventDrag.drags(key) {
+			pointer.CursorGrabbing.Add(gtx.Ops)
+		}
+	}
+	gtx.Constraints.Min, gtx.Constraints.Max = size, size
+	bgColor := event.Color
+	if bgColor.A == 0 {
+		bgColor = s.EventBg
+	}
+	title := event.Title
+	if !event.AllDay {
+		title = fmt.Sprintf("%s %s", c.resolvedLocale().FormatTime(event.Start.In(c.location())), title)
+	}
+	// the title of the taller blocks of the week and day views is at their top
+	direction := layout.W
+	if size.Y > gtx.Dp(s.EventHeight)*3/2 {
+		direction = layout.NW
+	}
+	return chip.Layout(gtx, func(gtx Gtx) Dim {
+		inset := Inset{Left: 2, Right: 2}
+		return inset.Layout(gtx, func(gtx Gtx) Dim {
+			rect := image.Rectangle{Max: gtx.Constraints.Min}
+			paint.FillShape(gtx.Ops, bgColor, clip.UniformRRect(rect, gtx.Dp(4)).Op(gtx.Ops))
+			return direction.Layout(gtx, func(gtx Gtx) Dim {
+				inset := Inset{Left: 4, Right: 4}
+				return inset.Layout(gtx, func(gtx Gtx) Dim {
+					label := material.Label(s.Theme, s.EventTextSize, title)
+					label.Color = s.EventFg
+					label.MaxLines = 1
+					label.Alignment = text.Start
+					return label.Layout(gtx)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,157 @@
+package giowidgets
+
+import (
+	"gioui.org/io/key"
+	"time"
+)
+
+// calendarKeys is the set of keys handled by the Calendar while it has the focus.
+const calendarKeys = key.Set("←|→|↑|↓|⇱|⇲|⏎|⌤|Space|⎋|[⇞,⇟]|Shift-[⇞,⇟]")
+
+// Focus requests the keyboard focus for the Calendar.
+func (c *Calendar) Focus() {
+	c.requestFocus = true
+}
+
+// Focused reports whether the Calendar has the keyboard focus.
+func (c *Calendar) Focused() bool {
+	return c.focused
+}
+
+// FocusedDate returns the day moved with the keyboard.
+func (c *Calendar) FocusedDate() time.Time {
+	if c.focusedDate.IsZero() || !c.isMonthVisible(c.focusedDate) {
+		return c.Time()
+	}
+	return c.focusedDate
+}
+
+func (c *Calendar) processKeys(gtx Gtx) {
+	for _, e := range gtx.Events(&c.keyTag) {
+		switch e := e.(type) {
+		case key.FocusEvent:
+			c.focused = e.Focus
+		case key.Event:
+			if e.State == key.Press {
+				c.onKey(e)
+			}
+		}
+	}
+}
+
+func (c *Calendar) onKey(e key.Event) {
+	switch c.View {
+	case CalendarYearView, CalendarDecadeView:
+		c.onZoomKey(e)
+		return
+	case CalendarWeekView, CalendarDayView:
+		c.onTimelineKey(e)
+		return
+	}
+	focused := c.FocusedDate()
+	switch e.Name {
+	case key.NameLeftArrow:
+		c.moveFocus(focused.AddDate(0, 0, -1), -1)
+	case key.NameRightArrow:
+		c.moveFocus(focused.AddDate(0, 0, 1), 1)
+	case key.NameUpArrow:
+		c.moveFocus(focused.AddDate(0, 0, -7), -1)
+	case key.NameDownArrow:
+		c.moveFocus(focused.AddDate(0, 0, 7), 1)
+	case key.NamePageUp:
+		if e.Modifiers.Contain(key.ModShift) {
+			c.moveFocus(addYears(c.system(), focused, -1), -1)
+		} else {
+			c.moveFocus(addMonths(c.system(), focused, -1), -1)
+		}
+	case key.NamePageDown:
+		if e.Modifiers.Contain(key.ModShift) {
+			c.moveFocus(addYears(c.system(), focused, 1), 1)
+		} else {
+			c.moveFocus(addMonths(c.system(), focused, 1), 1)
+		}
+	case key.NameHome:
+		c.moveFocus(c.weekModel().weekStart(focused), 1)
+	case key.NameEnd:
+		c.moveFocus(c.weekModel().weekStart(focused).AddDate(0, 0, 6), -1)
+	case key.NameReturn, key.NameEnter, key.NameSpace:
+		if !c.isDisabled(focused) {
+			c.onDateClick(focused)
+		}
+	case key.NameEscape:
+		c.ShowMonthsDropdown = false
+		c.showYearsDropdown = false
+	}
+}
+
+// onZoomKey moves Time through the items of the year and decade grids, picking the item zooms in.
+func (c *Calendar) onZoomKey(e key.Event) {
+	switch e.Name {
+	case key.NameLeftArrow:
+		c.moveZoomItem(-1)
+	case key.NameRightArrow:
+		c.moveZoomItem(1)
+	case key.NameUpArrow:
+		c.moveZoomItem(-zoomColumns)
+	case key.NameDownArrow:
+		c.moveZoomItem(zoomColumns)
+	case key.NamePageUp:
+		c.step(-1)
+	case key.NamePageDown:
+		c.step(1)
+	case key.NameReturn, key.NameEnter, key.NameSpace:
+		c.ZoomIn()
+	case key.NameEscape:
+		c.View = CalendarMonthView
+	}
+}
+
+// moveZoomItem moves Time by items of the year or decade grid, months or years.
+func (c *Calendar) moveZoomItem(items int) {
+	if c.View == CalendarDecadeView {
+		c.SetTime(c.clampTime(addYears(c.system(), c.Time(), items)))
+	} else {
+		c.SetTime(c.clampTime(addMonths(c.system(), c.Time(), items)))
+	}
+}
+
+// onTimelineKey moves Time by days in the week and day views.
+func (c *Calendar) onTimelineKey(e key.Event) {
+	switch e.Name {
+	case key.NameLeftArrow:
+		c.SetTime(c.clampTime(c.Time().AddDate(0, 0, -1)))
+	case key.NameRightArrow:
+		c.SetTime(c.clampTime(c.Time().AddDate(0, 0, 1)))
+	case key.NamePageUp:
+		c.step(-1)
+	case key.NamePageDown:
+		c.step(1)
+	case key.NameEscape:
+		c.View = CalendarMonthView
+	}
+}
+
+// moveFocus focuses the first enabled day found from t, stepping by step days over the disabled ones.
+// The focus stays where it is when no enabled day is found within a year.
+func (c *Calendar) moveFocus(t time.Time, step int) {
+	for i := 0; i < 366; i++ {
+		if !c.isDisabled(t) {
+			c.focusedDate = t
+			if !c.isMonthVisible(t) {
+				// moving forward keeps the focused day in the last displayed month
+				first := t
+				if t.After(c.Time()) {
+					first = addMonths(c.system(), t, 1-c.monthsCount())
+				}
+				c.SetTime(withClock(first, c.Time()))
+			}
+			return
+		}
+		t = t.AddDate(0, 0, step)
+	}
+}
+
+// isFocusedDate reports whether the focus ring should be drawn around the day t.
+func (c *Calendar) isFocusedDate(t time.Time) bool {
+	return c.focused && sameDay(c.FocusedDate(), t)
+}
@@ -0,0 +1,181 @@
+package giowidgets
+
+import (
+	"fmt"
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+	"time"
+)
+
+// monthView holds the state of one of the month grids displayed by the Calendar.
+type monthView struct {
+	// month is the first day of the displayed month
+	month        time.Time
+	rows         int
+	cellItemsArr []*cellItem
+	weekButtons  [6]widget.Clickable
+	// events overlap the days of the grid, eventChips are indexed by the week row
+	events      []Event
+	eventChips  [6]eventChips
+	moreButtons [42]widget.Clickable
+}
+
+func newMonthView() *monthView {
+	view := &monthView{}
+	for i := 0; i < 42; i++ {
+		view.cellItemsArr = append(view.cellItemsArr, &cellItem{})
+	}
+	return view
+}
+
+// monthsCount returns the number of consecutive months displayed, starting with the month of Time.
+func (c *Calendar) monthsCount() int {
+	if c.MonthsToShow < 1 {
+		return 1
+	}
+	return c.MonthsToShow
+}
+
+// monthsPerRow returns the number of month grids laid out side by side.
+func (c *Calendar) monthsPerRow() int {
+	if c.MonthsPerRow < 1 || c.MonthsPerRow > c.monthsCount() {
+		return c.monthsCount()
+	}
+	return c.MonthsPerRow
+}
+
+// isMonthVisible reports whether the month of t is one of the displayed months.
+func (c *Calendar) isMonthVisible(t time.Time) bool {
+	months := monthsBetween(c.system(), c.Time(), t)
+	return months >= 0 && months < c.monthsCount()
+}
+
+// prepareMonthViews assigns the days of every displayed month to the cells of its grid.
+func (c *Calendar) prepareMonthViews() {
+	for len(c.monthViews) < c.monthsCount() {
+		c.monthViews = append(c.monthViews, newMonthView())
+	}
+	week := c.weekModel()
+	cs := c.system()
+	c.hoveredDate = time.Time{}
+	for i, view := range c.monthViews[:c.monthsCount()] {
+		view.month = addMonths(cs, beginningOfMonth(cs, c.Time()), i)
+		startDate, rows := week.monthGrid(cs, view.month)
+		view.rows = rows
+		month := cs.FromTime(view.month).Month
+		for j, cell := range view.cellItemsArr[:rows*7] {
+			cell.Time = startDate.AddDate(0, 0, j)
+			cell.inMonth = cs.FromTime(cell.Time).Month == month
+			if cell.inMonth && cell.Hovered() && !c.isDisabled(cell.Time) {
+				c.hoveredDate = cell.Time
+			}
+		}
+		c.loadEvents(view)
+		c.loadHolidays(view)
+	}
+}
+
+// drawMonths lays out the grids of the displayed months, MonthsPerRow grids per row.
+func (s *CalendarStyle) drawMonths(gtx Gtx) Dim {
+	c := s.Calendar
+	c.prepareMonthViews()
+	views := c.monthViews[:c.monthsCount()]
+	perRow := c.monthsPerRow()
+	s.layoutCells(gtx, views)
+	var rows []FlexChild
+	for start := 0; start < len(views); start += perRow {
+		end := start + perRow
+		if end > len(views) {
+			end = len(views)
+		}
+		rowViews := views[start:end]
+		if start > 0 {
+			rows = append(rows, layout.Rigid(layout.Spacer{Height: s.MonthSpacing}.Layout))
+		}
+		rows = append(rows, layout.Rigid(func(gtx Gtx) Dim {
+			var children []FlexChild
+			for i, view := range rowViews {
+				view := view
+				if i > 0 {
+					children = append(children, layout.Rigid(layout.Spacer{Width: s.MonthSpacing}.Layout))
+				}
+				children = append(children, layout.Rigid(func(gtx Gtx) Dim {
+					return s.drawMonth(gtx, view)
+				}))
+			}
+			flex := Flex{}
+			return flex.Layout(gtx, children...)
+		}))
+	}
+	c.viewList.Axis = layout.Vertical
+	return c.viewList.Layout(gtx, 1, func(gtx Gtx, index int) Dim {
+		flex := Flex{Axis: layout.Vertical}
+		return flex.Layout(gtx, rows...)
+	})
+}
+
+// layoutCells sizes the day cells to fill the width of the grids and the height left to them,
+// within the MinCellSize and MaxCellSize of the style.
+func (s *CalendarStyle) layoutCells(gtx Gtx, views []*monthView) {
+	c := s.Calendar
+	monthRows := (len(views) + c.monthsPerRow() - 1) / c.monthsPerRow()
+	weeks := 0
+	for _, view := range views {
+		if view.rows > weeks {
+			weeks = view.rows
+		}
+	}
+	headers := gtx.Dp(s.MonthsHeaderRowHeight)
+	if c.monthsCount() > 1 {
+		headers += gtx.Dp(s.ViewHeaderHeight)
+	}
+	height := gtx.Constraints.Max.Y - monthRows*headers - (monthRows-1)*gtx.Dp(s.MonthSpacing)
+	c.cellSize.X = s.clampCellSize(gtx, c.gridWidth/c.columnsCount())
+	c.cellSize.Y = s.clampCellSize(gtx, height/(monthRows*weeks))
+	if gtx.Constraints.Max.Y > gtx.Dp(maxGridHeight) {
+		// like inside a vertical List
+		c.cellSize.Y = c.cellSize.X
+	}
+}
+
+// maxGridHeight is the height above which the Calendar is considered unbounded and its cells square.
+const maxGridHeight = unit.Dp(8192)
+
+// clampCellSize keeps size, the width or the height of a cell, within MinCellSize and MaxCellSize.
+func (s *CalendarStyle) clampCellSize(gtx Gtx, size int) int {
+	if max := gtx.Dp(s.MaxCellSize); max > 0 && size > max {
+		size = max
+	}
+	if min := gtx.Dp(s.MinCellSize); size < min {
+		size = min
+	}
+	return size
+}
+
+// drawMonth draws the grid of a single month, titled when several months are displayed.
+func (s *CalendarStyle) drawMonth(gtx Gtx, view *monthView) Dim {
+	c := s.Calendar
+	gtx.Constraints.Max.X = c.gridWidth
+	flex := Flex{Axis: layout.Vertical}
+	return flex.Layout(gtx,
+		layout.Rigid(func(gtx Gtx) Dim {
+			if c.monthsCount() == 1 {
+				return Dim{}
+			}
+			date := c.system().FromTime(view.month)
+			title := fmt.Sprintf("%s %d", c.system().MonthName(date.Month, c.resolvedLocale()), date.Year)
+			gtx.Constraints.Min.X = c.cellSize.X * c.columnsCount()
+			gtx.Constraints.Min.Y, gtx.Constraints.Max.Y = gtx.Dp(s.ViewHeaderHeight), gtx.Dp(s.ViewHeaderHeight)
+			label := material.Label(s.Theme, s.TextSize, title)
+			label.Color = s.TitleFg
+			label.MaxLines = 1
+			return layout.Center.Layout(gtx, label.Layout)
+		}),
+		layout.Rigid(s.drawHeaderRow),
+		layout.Rigid(func(gtx Gtx) Dim {
+			return s.drawBodyRows(gtx, view)
+		}),
+	)
+}
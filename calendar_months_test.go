@@ -0,0 +1,31 @@
+package giowidgets
+
+import (
+	"gioui.org/font/gofont"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/widget/material"
+	"image"
+	"testing"
+	"time"
+)
+
+func TestCellsFillLargeArea(t *testing.T) {
+	th := material.NewTheme(gofont.Collection())
+	for _, size := range []image.Point{{X: 2400, Y: 1600}, {X: 1200, Y: 3000}, {X: 400, Y: 300}} {
+		c := Calendar{}
+		c.SetTime(time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC))
+		style := NewCalendarStyle(th, &c)
+		var ops op.Ops
+		gtx := layout.Context{Ops: &ops, Constraints: layout.Exact(size)}
+		style.Layout(gtx)
+		width := size.X - gtx.Dp(style.Inset.Left) - gtx.Dp(style.Inset.Right)
+		if filled := c.cellSize.X * 7; filled < width-7 || filled > width {
+			t.Errorf("%v: 7 cells of %v fill %d of the %d wide grid", size, c.cellSize, filled, width)
+		}
+		// the March 2024 grid has 6 rows, they fill the height left below the headers
+		if c.cellSize.Y*6 < size.Y/2 {
+			t.Errorf("%v: 6 rows of %v don't stretch to the height", size, c.cellSize)
+		}
+	}
+}
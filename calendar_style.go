@@ -0,0 +1,228 @@
+package giowidgets
+
+import (
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget/material"
+	"golang.org/x/exp/shiny/materialdesign/colornames"
+	"image/color"
+)
+
+// CalendarStyle draws a Calendar, it holds the colors, typography and spacing while the Calendar
+// holds the state. It is built by NewCalendarStyle and can be adjusted before calling Layout:
+//
+//	style := giowidgets.NewCalendarStyle(th, &calendar)
+//	style.SelectedBg = brandColor
+//	style.Layout(gtx)
+type CalendarStyle struct {
+	Calendar *Calendar
+	// Theme provides the text shaper of the labels.
+	Theme *material.Theme
+
+	// CellBg and CellFg color the days of the displayed month.
+	CellBg color.NRGBA
+	CellFg color.NRGBA
+	// OutOfMonthBg and OutOfMonthFg color the days of the neighbouring months.
+	OutOfMonthBg color.NRGBA
+	OutOfMonthFg color.NRGBA
+	// WeekendFg colors the weekend days of the displayed month.
+	WeekendFg color.NRGBA
+	// DisabledFg colors the days which can't be selected.
+	DisabledFg color.NRGBA
+	TodayBg    color.NRGBA
+	TodayFg    color.NRGBA
+	SelectedBg color.NRGBA
+	SelectedFg color.NRGBA
+	HoverBg    color.NRGBA
+	HoverFg    color.NRGBA
+	// RangeBg fills the days between the ends of a range, RangeOutsideBg those outside of the month.
+	RangeBg        color.NRGBA
+	RangeOutsideBg color.NRGBA
+	FocusRing      color.NRGBA
+	// HeaderBg and HeaderFg color the row of weekdays.
+	HeaderBg color.NRGBA
+	HeaderFg color.NRGBA
+	// WeekNumberBg and WeekNumberFg color the week numbers column.
+	WeekNumberBg color.NRGBA
+	WeekNumberFg color.NRGBA
+	// TitleFg colors the month and year of the header and the titles of the grids.
+	TitleFg color.NRGBA
+	// IconColor colors the dropdown arrows and the navigation chevrons, DisabledIconColor the
+	// chevrons leading out of MinDate and MaxDate.
+	IconColor          color.NRGBA
+	DisabledIconColor  color.NRGBA
+	DropdownBg         color.NRGBA
+	DropdownFg         color.NRGBA
+	DropdownSelectedBg color.NRGBA
+	DropdownSelectedFg color.NRGBA
+	DropdownBorder     color.NRGBA
+	// BadgeBg is used by the badges having a transparent DayDecoration.BadgeColor.
+	BadgeBg color.NRGBA
+	BadgeFg color.NRGBA
+	// EventBg fills the bars of the events having a transparent Color, EventFg colors their title.
+	EventBg color.NRGBA
+	EventFg color.NRGBA
+	// HolidayBg and HolidayFg color the holidays of the displayed month.
+	HolidayBg color.NRGBA
+	HolidayFg color.NRGBA
+	// TooltipBg and TooltipFg color the names of the holidays shown when hovering their days.
+	TooltipBg color.NRGBA
+	TooltipFg color.NRGBA
+	// DropTargetBg highlights the days and the time slots an event is dragged to.
+	DropTargetBg color.NRGBA
+	// MoreEventsFg colors the "+N more" chips of the cells having more events than fit.
+	MoreEventsFg color.NRGBA
+	// SlotLine separates the time slots of the week and day views, at half its alpha within an hour.
+	SlotLine color.NRGBA
+	// NowLine marks the current time in the week and day views.
+	NowLine color.NRGBA
+
+	// TextSize is the size of the header, dropdown and title labels.
+	TextSize unit.Sp
+	// DayTextSize is the size of the day numbers.
+	DayTextSize unit.Sp
+	// CompactTextSize replaces the day and weekday sizes in the compact layout.
+	CompactTextSize unit.Sp
+	// EventTextSize is the size of the event titles.
+	EventTextSize unit.Sp
+	// BadgeTextSize is the size of the counts of the day badges.
+	BadgeTextSize unit.Sp
+
+	layout.Inset
+	// CellInset pads the content of the day cells.
+	CellInset             layout.Inset
+	MonthsHeaderRowHeight unit.Dp
+	ViewHeaderHeight      unit.Dp
+	DropdownWidth         unit.Dp
+	// HeaderSpacing separates the months and years dropdowns.
+	HeaderSpacing unit.Dp
+	// MonthSpacing separates the grids when several months are displayed.
+	MonthSpacing unit.Dp
+	// CompactWidth and ExpandedWidth are the grid widths at which the compact and the expanded densities start.
+	CompactWidth  unit.Dp
+	ExpandedWidth unit.Dp
+	// MinCellSize and MaxCellSize bound the width and the height of the day cells, which otherwise
+	// stretch to fill the Calendar. A zero MaxCellSize, the default, leaves them unbounded, the cells
+	// are then square when the height of the Calendar is unbounded.
+	MinCellSize unit.Dp
+	MaxCellSize unit.Dp
+	// EventHeight is the height of the event bars in the month grids.
+	EventHeight unit.Dp
+	// SlotHeight is the height of a time slot of the week and day views.
+	SlotHeight unit.Dp
+	// HourAxisWidth is the width of the column displaying the hours in the week and day views.
+	HourAxisWidth unit.Dp
+}
+
+// CalendarDensity is chosen from the width of the grids by the CompactWidth and ExpandedWidth breakpoints.
+type CalendarDensity int
+
+const (
+	// DensityRegular displays the short weekday names.
+	DensityRegular CalendarDensity = iota
+	// DensityCompact displays the narrow weekday names and smaller labels.
+	DensityCompact
+	// DensityExpanded displays the full weekday names.
+	DensityExpanded
+)
+
+// NewCalendarStyle returns the style drawing calendar with the colors of th. The deprecated inset
+// and sizes still set on calendar are copied.
+func NewCalendarStyle(th *material.Theme, calendar *Calendar) CalendarStyle {
+	withAlpha := func(c color.NRGBA, alpha uint8) color.NRGBA {
+		c.A = alpha
+		return c
+	}
+	s := CalendarStyle{
+		Calendar:              calendar,
+		Theme:                 th,
+		CellBg:                th.Bg,
+		CellFg:                withAlpha(th.Fg, 210),
+		OutOfMonthBg:          color.NRGBA(colornames.BlueGrey50),
+		OutOfMonthFg:          withAlpha(th.Fg, 100),
+		WeekendFg:             withAlpha(th.ContrastBg, 210),
+		DisabledFg:            withAlpha(th.Fg, 60),
+		TodayBg:               withAlpha(th.ContrastBg, 240),
+		TodayFg:               withAlpha(th.ContrastFg, 240),
+		SelectedBg:            th.ContrastBg,
+		SelectedFg:            th.ContrastFg,
+		HoverBg:               withAlpha(th.ContrastBg, 240),
+		HoverFg:               withAlpha(th.ContrastFg, 240),
+		RangeBg:               withAlpha(th.ContrastBg, 100),
+		RangeOutsideBg:        withAlpha(th.ContrastBg, 50),
+		FocusRing:             th.Fg,
+		HeaderBg:              th.ContrastBg,
+		HeaderFg:              th.ContrastFg,
+		WeekNumberBg:          withAlpha(th.ContrastBg, 40),
+		WeekNumberFg:          withAlpha(th.Fg, 160),
+		TitleFg:               th.Fg,
+		IconColor:             th.ContrastBg,
+		DisabledIconColor:     withAlpha(th.ContrastBg, 100),
+		DropdownBg:            th.Bg,
+		DropdownFg:            th.Fg,
+		DropdownSelectedBg:    th.Fg,
+		DropdownSelectedFg:    th.Bg,
+		DropdownBorder:        th.ContrastBg,
+		BadgeBg:               th.ContrastBg,
+		BadgeFg:               th.ContrastFg,
+		EventBg:               th.ContrastBg,
+		EventFg:               th.ContrastFg,
+		MoreEventsFg:          th.Fg,
+		DropTargetBg:          withAlpha(th.ContrastBg, 60),
+		HolidayBg:             withAlpha(color.NRGBA(colornames.Red500), 30),
+		HolidayFg:             color.NRGBA(colornames.Red700),
+		TooltipBg:             withAlpha(th.Fg, 230),
+		TooltipFg:             th.Bg,
+		SlotLine:              withAlpha(th.Fg, 60),
+		NowLine:               color.NRGBA(colornames.Red500),
+		TextSize:              th.TextSize,
+		DayTextSize:           th.TextSize * 1.5,
+		CompactTextSize:       unit.Sp(14),
+		EventTextSize:         unit.Sp(11),
+		BadgeTextSize:         unit.Sp(10),
+		Inset:                 calendar.Inset,
+		CellInset:             layout.UniformInset(unit.Dp(8)),
+		MonthsHeaderRowHeight: calendar.monthsHeaderRowHeight,
+		ViewHeaderHeight:      calendar.viewHeaderHeight,
+		DropdownWidth:         calendar.dropdownWidth,
+		HeaderSpacing:         unit.Dp(32),
+		MonthSpacing:          unit.Dp(16),
+		CompactWidth:          unit.Dp(500),
+		ExpandedWidth:         unit.Dp(840),
+		MinCellSize:           unit.Dp(24),
+		EventHeight:           unit.Dp(16),
+		SlotHeight:            unit.Dp(24),
+		HourAxisWidth:         unit.Dp(64),
+	}
+	if s.MonthsHeaderRowHeight == 0 {
+		s.MonthsHeaderRowHeight = defaultMonthsHeaderRowHeight
+	}
+	if s.ViewHeaderHeight == 0 {
+		s.ViewHeaderHeight = defaultViewHeaderHeight
+	}
+	if s.DropdownWidth == 0 {
+		s.DropdownWidth = defaultDropdownWidth
+	}
+	return s
+}
+
+func (s CalendarStyle) Layout(gtx Gtx) Dim {
+	return s.layout(gtx)
+}
+
+// density returns the density of the grids.
+func (s *CalendarStyle) density(gtx Gtx) CalendarDensity {
+	c := s.Calendar
+	switch {
+	case c.gridWidth < gtx.Dp(s.CompactWidth):
+		return DensityCompact
+	case c.gridWidth >= gtx.Dp(s.ExpandedWidth):
+		return DensityExpanded
+	}
+	return DensityRegular
+}
+
+// compact reports whether the grids are too narrow for the full labels.
+func (s *CalendarStyle) compact(gtx Gtx) bool {
+	return s.density(gtx) == DensityCompact
+}
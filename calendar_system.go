@@ -0,0 +1,265 @@
+package giowidgets
+
+import (
+	"time"
+)
+
+// CalendarDate is a day expressed in the numbering of a CalendarSystem, months and days start at 1.
+type CalendarDate struct {
+	Year  int
+	Month int
+	Day   int
+}
+
+// CalendarSystem converts the days displayed by the Calendar to and from time.Time.
+type CalendarSystem interface {
+	// FromTime returns the date of the day of t, in the location of t.
+	FromTime(t time.Time) CalendarDate
+	// ToTime returns the midnight starting date in loc.
+	ToTime(date CalendarDate, loc *time.Location) time.Time
+	MonthsInYear(year int) int
+	DaysInMonth(year, month int) int
+	MonthName(month int, locale Locale) string
+}
+
+var (
+	// Gregorian is the proleptic Gregorian calendar used by time.Time and ISO 8601.
+	Gregorian CalendarSystem = gregorianSystem{}
+	// Persian is the Solar Hijri (Jalali) calendar used in Iran and Afghanistan.
+	Persian CalendarSystem = persianSystem{}
+	// ThaiBuddhist is the Gregorian calendar counting the years of the Buddhist Era.
+	ThaiBuddhist CalendarSystem = thaiBuddhistSystem{}
+	// IslamicTabular is the arithmetical Hijri calendar with the civil epoch of 16 July 622.
+	IslamicTabular CalendarSystem = islamicTabularSystem{}
+)
+
+// unixEpochJulianDay is the Julian day number of 1 January 1970.
+const unixEpochJulianDay = 2440588
+
+// julianDay returns the Julian day number of a Gregorian date.
+func julianDay(year int, month time.Month, day int) int {
+	return int(time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix()/86400) + unixEpochJulianDay
+}
+
+// gregorianDate returns the Gregorian date of the Julian day number jdn.
+func gregorianDate(jdn int) (year int, month time.Month, day int) {
+	return time.Unix(int64(jdn-unixEpochJulianDay)*86400, 0).UTC().Date()
+}
+
+type gregorianSystem struct{}
+
+func (gregorianSystem) FromTime(t time.Time) CalendarDate {
+	year, month, day := t.Date()
+	return CalendarDate{Year: year, Month: int(month), Day: day}
+}
+
+func (gregorianSystem) ToTime(date CalendarDate, loc *time.Location) time.Time {
+	return time.Date(date.Year, time.Month(date.Month), date.Day, 0, 0, 0, 0, loc)
+}
+
+func (gregorianSystem) MonthsInYear(year int) int {
+	return 12
+}
+
+func (gregorianSystem) DaysInMonth(year, month int) int {
+	return time.Date(year, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func (gregorianSystem) MonthName(month int, locale Locale) string {
+	return locale.MonthName(time.Month(month))
+}
+
+// buddhistEraOffset is the number of years between the Buddhist Era and the Common Era.
+const buddhistEraOffset = 543
+
+type thaiBuddhistSystem struct{}
+
+func (thaiBuddhistSystem) FromTime(t time.Time) CalendarDate {
+	date := Gregorian.FromTime(t)
+	date.Year += buddhistEraOffset
+	return date
+}
+
+func (thaiBuddhistSystem) ToTime(date CalendarDate, loc *time.Location) time.Time {
+	date.Year -= buddhistEraOffset
+	return Gregorian.ToTime(date, loc)
+}
+
+func (thaiBuddhistSystem) MonthsInYear(year int) int {
+	return 12
+}
+
+func (thaiBuddhistSystem) DaysInMonth(year, month int) int {
+	return Gregorian.DaysInMonth(year-buddhistEraOffset, month)
+}
+
+func (thaiBuddhistSystem) MonthName(month int, locale Locale) string {
+	return locale.MonthName(time.Month(month))
+}
+
+var persianMonthNames = [12]string{
+	"Farvardin", "Ordibehesht", "Khordad", "Tir", "Mordad", "Shahrivar",
+	"Mehr", "Aban", "Azar", "Dey", "Bahman", "Esfand",
+}
+var persianNativeMonthNames = [12]string{
+	"فروردین", "اردیبهشت", "خرداد", "تیر", "مرداد", "شهریور",
+	"مهر", "آبان", "آذر", "دی", "بهمن", "اسفند",
+}
+
+// persianBreaks are the years at which the 33 year cycles of leap years of the Jalali calendar
+// change, see the jalaali-js reference implementation.
+var persianBreaks = [...]int{
+	-61, 9, 38, 199, 426, 686, 756, 818, 1111, 1181, 1210,
+	1635, 2060, 2097, 2192, 2262, 2324, 2394, 2456, 3178,
+}
+
+type persianSystem struct{}
+
+// persianYear returns the number of years since the last leap year (0 for a leap year), the Gregorian
+// year in which year starts and the day of March on which it starts.
+func persianYear(year int) (leap, gregorianYear, march int) {
+	gregorianYear = year + 621
+	leapJ := -14
+	jp := persianBreaks[0]
+	jump := 0
+	for _, jm := range persianBreaks[1:] {
+		jump = jm - jp
+		if year < jm {
+			break
+		}
+		leapJ += jump/33*8 + jump%33/4
+		jp = jm
+	}
+	n := year - jp
+	leapJ += n/33*8 + (n%33+3)/4
+	if jump%33 == 4 && jump-n == 4 {
+		leapJ++
+	}
+	leapG := gregorianYear/4 - (gregorianYear/100+1)*3/4 - 150
+	march = 20 + leapJ - leapG
+	if jump-n < 6 {
+		n = n - jump + (jump+4)/33*33
+	}
+	leap = ((n+1)%33 - 1) % 4
+	if leap == -1 {
+		leap = 4
+	}
+	return leap, gregorianYear, march
+}
+
+func (persianSystem) FromTime(t time.Time) CalendarDate {
+	year, month, day := t.Date()
+	jdn := julianDay(year, month, day)
+	persianYearNumber := year - 621
+	leap, gregorianYear, march := persianYear(persianYearNumber)
+	k := jdn - julianDay(gregorianYear, time.March, march)
+	if k >= 0 {
+		if k <= 185 {
+			return CalendarDate{Year: persianYearNumber, Month: 1 + k/31, Day: k%31 + 1}
+		}
+		k -= 186
+	} else {
+		persianYearNumber--
+		k += 179
+		if leap == 1 {
+			k++
+		}
+	}
+	return CalendarDate{Year: persianYearNumber, Month: 7 + k/30, Day: k%30 + 1}
+}
+
+func (persianSystem) ToTime(date CalendarDate, loc *time.Location) time.Time {
+	_, gregorianYear, march := persianYear(date.Year)
+	jdn := julianDay(gregorianYear, time.March, march) + (date.Month-1)*31 - date.Month/7*(date.Month-7) + date.Day - 1
+	year, month, day := gregorianDate(jdn)
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+func (persianSystem) MonthsInYear(year int) int {
+	return 12
+}
+
+func (persianSystem) DaysInMonth(year, month int) int {
+	switch {
+	case month <= 6:
+		return 31
+	case month <= 11:
+		return 30
+	}
+	if leap, _, _ := persianYear(year); leap == 0 {
+		return 30
+	}
+	return 29
+}
+
+func (persianSystem) MonthName(month int, locale Locale) string {
+	if base, _ := locale.Tag.Base(); base.String() == "fa" {
+		return persianNativeMonthNames[monthIndex(month)]
+	}
+	return persianMonthNames[monthIndex(month)]
+}
+
+var islamicMonthNames = [12]string{
+	"Muharram", "Safar", "Rabi' al-awwal", "Rabi' al-thani", "Jumada al-awwal", "Jumada al-thani",
+	"Rajab", "Sha'ban", "Ramadan", "Shawwal", "Dhu al-Qi'dah", "Dhu al-Hijjah",
+}
+var islamicNativeMonthNames = [12]string{
+	"محرم", "صفر", "ربيع الأول", "ربيع الآخر", "جمادى الأولى", "جمادى الآخرة",
+	"رجب", "شعبان", "رمضان", "شوال", "ذو القعدة", "ذو الحجة",
+}
+
+// islamicEpochJulianDay is the Julian day number of 1 Muharram 1 AH, 16 July 622 (Julian).
+const islamicEpochJulianDay = 1948440
+
+type islamicTabularSystem struct{}
+
+// islamicJulianDay returns the Julian day number of a tabular Islamic date.
+func islamicJulianDay(year, month, day int) int {
+	return day + (59*(month-1)+1)/2 + (year-1)*354 + (3+11*year)/30 + islamicEpochJulianDay - 1
+}
+
+func (islamicTabularSystem) FromTime(t time.Time) CalendarDate {
+	jdn := julianDay(t.Date())
+	year := (30*(jdn-islamicEpochJulianDay) + 10646) / 10631
+	for jdn < islamicJulianDay(year, 1, 1) {
+		year--
+	}
+	for jdn >= islamicJulianDay(year+1, 1, 1) {
+		year++
+	}
+	// the 30th of Dhu al-Hijjah of the leap years would be estimated in a 13th month
+	month := clampInt((jdn-islamicJulianDay(year, 1, 1))*2/59+1, 1, 12)
+	for month > 1 && jdn < islamicJulianDay(year, month, 1) {
+		month--
+	}
+	for month < 12 && jdn >= islamicJulianDay(year, month+1, 1) {
+		month++
+	}
+	return CalendarDate{Year: year, Month: month, Day: jdn - islamicJulianDay(year, month, 1) + 1}
+}
+
+func (islamicTabularSystem) ToTime(date CalendarDate, loc *time.Location) time.Time {
+	year, month, day := gregorianDate(islamicJulianDay(date.Year, date.Month, date.Day))
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+func (islamicTabularSystem) MonthsInYear(year int) int {
+	return 12
+}
+
+func (islamicTabularSystem) DaysInMonth(year, month int) int {
+	if month%2 == 1 {
+		return 30
+	}
+	if month == 12 && (14+11*year)%30 < 11 {
+		return 30
+	}
+	return 29
+}
+
+func (islamicTabularSystem) MonthName(month int, locale Locale) string {
+	if base, _ := locale.Tag.Base(); base.String() == "ar" {
+		return islamicNativeMonthNames[monthIndex(month)]
+	}
+	return islamicMonthNames[monthIndex(month)]
+}
@@ -0,0 +1,61 @@
+package giowidgets
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalendarSystemKnownDates(t *testing.T) {
+	tests := []struct {
+		system CalendarSystem
+		day    time.Time
+		want   CalendarDate
+	}{
+		{Gregorian, time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC), CalendarDate{2024, 2, 29}},
+		{ThaiBuddhist, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), CalendarDate{2567, 1, 1}},
+		{Persian, time.Date(2024, time.March, 20, 0, 0, 0, 0, time.UTC), CalendarDate{1403, 1, 1}},
+		{Persian, time.Date(2025, time.March, 20, 0, 0, 0, 0, time.UTC), CalendarDate{1403, 12, 30}},
+		{Persian, time.Date(2025, time.March, 21, 0, 0, 0, 0, time.UTC), CalendarDate{1404, 1, 1}},
+		{Persian, time.Date(1979, time.February, 11, 0, 0, 0, 0, time.UTC), CalendarDate{1357, 11, 22}},
+		{IslamicTabular, time.Date(1979, time.November, 21, 0, 0, 0, 0, time.UTC), CalendarDate{1400, 1, 1}},
+		{IslamicTabular, time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC), CalendarDate{1420, 9, 24}},
+	}
+	for _, test := range tests {
+		if got := test.system.FromTime(test.day); got != test.want {
+			t.Errorf("%T.FromTime(%v) = %v, want %v", test.system, test.day.Format("2006-01-02"), got, test.want)
+		}
+		if got := test.system.ToTime(test.want, time.UTC); !got.Equal(test.day) {
+			t.Errorf("%T.ToTime(%v) = %v, want %v", test.system, test.want, got.Format("2006-01-02"), test.day.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestCalendarSystemRoundTrip(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tehran")
+	if err != nil {
+		t.Skip(err)
+	}
+	for _, system := range []CalendarSystem{Gregorian, ThaiBuddhist, Persian, IslamicTabular} {
+		previous := system.FromTime(time.Date(1949, time.December, 31, 0, 0, 0, 0, loc))
+		for utc := time.Date(1950, time.January, 1, 0, 0, 0, 0, time.UTC); utc.Year() <= 2100; utc = utc.AddDate(0, 0, 1) {
+			// the midnights skipped by a change of clocks are normalized by time.Date, like in ToTime
+			day := time.Date(utc.Year(), utc.Month(), utc.Day(), 0, 0, 0, 0, loc)
+			date := system.FromTime(day)
+			if got := system.ToTime(date, loc); !got.Equal(day) {
+				t.Fatalf("%T: %v is %v, which converts back to %v", system, day.Format("2006-01-02"), date, got.Format("2006-01-02"))
+			}
+			// every day follows the previous one in the numbering of the system
+			next := CalendarDate{Year: previous.Year, Month: previous.Month, Day: previous.Day + 1}
+			if next.Day > system.DaysInMonth(next.Year, next.Month) {
+				next.Month, next.Day = next.Month+1, 1
+			}
+			if next.Month > system.MonthsInYear(next.Year) {
+				next.Year, next.Month = next.Year+1, 1
+			}
+			if date != next {
+				t.Fatalf("%T: %v is %v, want %v after %v", system, day.Format("2006-01-02"), date, next, previous)
+			}
+			previous = date
+		}
+	}
+}
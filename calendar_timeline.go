@@ -0,0 +1,383 @@
+package giowidgets
+
+import (
+	"fmt"
+	"gioui.org/f32"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/text"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+	"image"
+	"math"
+	"sort"
+	"time"
+)
+
+type OnSlotClick func(start, end time.Time)
+
+const defaultSlotLength = 30 * time.Minute
+
+// slotLength returns SlotLength rounded to the nearest of 15, 30 and 60 minutes, defaulting to 30 minutes.
+func (c *Calendar) slotLength() time.Duration {
+	switch {
+	case c.SlotLength <= 0:
+		return defaultSlotLength
+	case c.SlotLength < 22*time.Minute+30*time.Second:
+		return 15 * time.Minute
+	case c.SlotLength < 45*time.Minute:
+		return 30 * time.Minute
+	}
+	return time.Hour
+}
+
+// isWorkingTime reports whether the slot starting at offset from midnight of day lies within the working hours.
+// Every slot is a working one when no working hours are set.
+func (c *Calendar) isWorkingTime(day time.Time, offset time.Duration) bool {
+	if c.WorkingHoursEnd <= c.WorkingHoursStart {
+		return true
+	}
+	if c.resolvedLocale().IsWeekend(day.Weekday()) {
+		return false
+	}
+	return offset >= c.WorkingHoursStart && offset < c.WorkingHoursEnd
+}
+
+// drawTimeline draws the time slots of days consecutive days starting with the day of first,
+// under a header row naming the days.
+func (s *CalendarStyle) drawTimeline(gtx Gtx, first time.Time, days int) Dim {
+	c := s.Calendar
+	year, month, day := first.Date()
+	first = time.Date(year, month, day, 0, 0, 0, 0, first.Location())
+	slotLength := c.slotLength()
+	slots := int(24 * time.Hour / slotLength)
+	axisWidth := gtx.Dp(s.HourAxisWidth)
+	columnWidth := (c.maxWidth - axisWidth) / days
+	if !c.slotsScrolled {
+		c.slotList.Position.First = int(c.WorkingHoursStart / slotLength)
+		c.slotsScrolled = true
+	}
+	now := c.now()
+	for len(c.slotButtons) < slots*7 {
+		c.slotButtons = append(c.slotButtons, widget.Clickable{})
+	}
+	if !c.today.Before(first) && c.today.Before(first.AddDate(0, 0, days)) {
+		// moves the current time line
+		op.InvalidateOp{At: gtx.Now.Add(time.Minute)}.Add(gtx.Ops)
+	}
+	var events []Event
+	if c.EventSource != nil {
+		events = c.EventSource.Events(first, first.AddDate(0, 0, days))
+	}
+	flex := Flex{Axis: layout.Vertical}
+	return flex.Layout(gtx,
+		layout.Rigid(func(gtx Gtx) Dim {
+			return s.drawTimelineHeader(gtx, first, days, axisWidth, columnWidth)
+		}),
+		layout.Rigid(func(gtx Gtx) Dim {
+			return s.drawAllDayEvents(gtx, events, first, days, axisWidth, columnWidth)
+		}),
+		layout.Flexed(1, func(gtx Gtx) Dim {
+			c.slotList.Axis = layout.Vertical
+			d := c.slotList.Layout(gtx, slots, func(gtx Gtx, slot int) Dim {
+				return s.drawSlotRow(gtx, first, now, days, slot, axisWidth, columnWidth)
+			})
+			s.drawTimelineEvents(gtx, events, first, days, axisWidth, columnWidth, d.Size)
+			return d
+		}),
+	)
+}
+
+// drawAllDayEvents draws the all-day events of the days consecutive days starting on the midnight
+// first in a strip above the time slots, laid out in lanes like in the month grids. The strip is
+// omitted when there are none.
+func (s *CalendarStyle) drawAllDayEvents(gtx Gtx, events []Event, first time.Time, days, axisWidth, columnWidth int) Dim {
+	c := s.Calendar
+	var allDay []Event
+	for _, e := range events {
+		if e.AllDay {
+			allDay = append(allDay, e)
+		}
+	}
+	moved, dragged := c.movedEvent()
+	dragged = dragged && moved.AllDay
+	if len(allDay) == 0 && !dragged {
+		return Dim{}
+	}
+	segments := layoutRowEvents(allDay, first, days)
+	lanes := 1
+	for _, segment := range segments {
+		if segment.lane+1 > lanes {
+			lanes = segment.lane + 1
+		}
+	}
+	spacing := gtx.Dp(eventSpacing)
+	laneHeight := gtx.Dp(s.EventHeight) + spacing
+	size := image.Point{X: axisWidth + days*columnWidth, Y: lanes*laneHeight + spacing}
+	defer clip.Rect{Max: size}.Push(gtx.Ops).Pop()
+	paint.FillShape(gtx.Ops, s.CellBg, clip.Rect{Max: size}.Op())
+	if dragged {
+		// highlights the days the dragged event is dropped on
+		firstDay, lastDay := moved.days(first.Location())
+		for column := 0; column < days; column++ {
+			day := first.AddDate(0, 0, column)
+			if compareDays(day, firstDay) >= 0 && compareDays(day, lastDay) <= 0 {
+				rect := image.Rect(axisWidth+column*columnWidth, 0, axisWidth+(column+1)*columnWidth, size.Y)
+				paint.FillShape(gtx.Ops, s.DropTargetBg, clip.Rect(rect).Op())
+			}
+		}
+	}
+	c.allDayChips.frame()
+	for _, segment := range segments {
+		offset := image.Point{X: axisWidth + segment.first*columnWidth, Y: spacing + segment.lane*laneHeight}
+		barSize := image.Point{X: (segment.last - segment.first + 1) * columnWidth, Y: laneHeight - spacing}
+		event, firstColumn := segment.event, segment.first
+		target := func(press, pos f32.Point) time.Time {
+			// the days under the pointer when pressed and now
+			column := func(p f32.Point) int {
+				return floorDiv(float32(firstColumn*columnWidth)+p.X, columnWidth)
+			}
+			year, month, day := event.Start.Date()
+			hour, minute, second := event.Start.Clock()
+			day += clampInt(column(pos), 0, days-1) - column(press)
+			return time.Date(year, month, day, hour, minute, second, event.Start.Nanosecond(), event.Start.Location())
+		}
+		chip, key := c.allDayChips.chip(event)
+		stack := op.Offset(offset).Push(gtx.Ops)
+		s.drawEventBar(gtx, chip, key, event, barSize, target)
+		stack.Pop()
+	}
+	line := image.Rect(0, size.Y-gtx.Dp(1), size.X, size.Y)
+	paint.FillShape(gtx.Ops, s.SlotLine, clip.Rect(line).Op())
+	return Dim{Size: size}
+}
+
+// drawTimelineHeader draws the header row of the week and day views, naming each day.
+func (s *CalendarStyle) drawTimelineHeader(gtx Gtx, first time.Time, days, axisWidth, columnWidth int) Dim {
+	c := s.Calendar
+	locale := c.resolvedLocale()
+	flexChildren := []FlexChild{s.drawHeaderColumn(gtx, "", "", axisWidth)}
+	for i := 0; i < days; i++ {
+		day := first.AddDate(0, 0, i)
+		dayNumber := c.date(day).Day
+		label := fmt.Sprintf("%s %d", locale.Upper(locale.ShortWeekdayNames[day.Weekday()]), dayNumber)
+		narrowLabel := fmt.Sprintf("%s %d", locale.NarrowWeekdayNames[day.Weekday()], dayNumber)
+		flexChildren = append(flexChildren, s.drawHeaderColumn(gtx, label, narrowLabel, columnWidth))
+	}
+	return s.layoutHeaderRow(gtx, flexChildren)
+}
+
+// drawSlotRow draws the slot of every day starting at the given slot index, preceded by the hour axis.
+func (s *CalendarStyle) drawSlotRow(gtx Gtx, first, now time.Time, days, slot, axisWidth, columnWidth int) Dim {
+	c := s.Calendar
+	slotLength := c.slotLength()
+	offset := time.Duration(slot) * slotLength
+	height := gtx.Dp(s.SlotHeight)
+	flexChildren := []FlexChild{
+		layout.Rigid(func(gtx Gtx) Dim {
+			gtx.Constraints.Min = image.Point{X: axisWidth, Y: height}
+			gtx.Constraints.Max = gtx.Constraints.Min
+			if offset%time.Hour != 0 {
+				return Dim{Size: gtx.Constraints.Min}
+			}
+			year, month, day := first.Date()
+			hour := time.Date(year, month, day, 0, 0, 0, 0, first.Location()).Add(offset)
+			inset := Inset{Right: 8}
+			return inset.Layout(gtx, func(gtx Gtx) Dim {
+				return layout.NE.Layout(gtx, func(gtx Gtx) Dim {
+					label := material.Label(s.Theme, s.CompactTextSize, c.resolvedLocale().FormatTime(hour))
+					label.Color = s.WeekNumberFg
+					label.MaxLines = 1
+					label.Alignment = text.End
+					return label.Layout(gtx)
+				})
+			})
+		}),
+	}
+	for i := 0; i < days; i++ {
+		year, month, day := first.AddDate(0, 0, i).Date()
+		start := time.Date(year, month, day, 0, int(offset/time.Minute), 0, 0, first.Location())
+		btn := &c.slotButtons[slot*7+i]
+		flexChildren = append(flexChildren, layout.Rigid(func(gtx Gtx) Dim {
+			gtx.Constraints.Min = image.Point{X: columnWidth, Y: height}
+			gtx.Constraints.Max = gtx.Constraints.Min
+			return s.drawSlot(gtx, btn, start, start.Add(slotLength), now, offset)
+		}))
+	}
+	flex := Flex{}
+	return flex.Layout(gtx, flexChildren...)
+}
+
+// drawSlot draws the time slot from start to end, marking now when it falls within the slot.
+// offset is the time of start from midnight.
+func (s *CalendarStyle) drawSlot(gtx Gtx, btn *widget.Clickable, start, end, now time.Time, offset time.Duration) Dim {
+	c := s.Calendar
+	disabled := c.isDisabled(start)
+	if !disabled && btn.Clicked() {
+		c.requestFocus = true
+		if c.OnSlotClick != nil {
+			c.OnSlotClick(start, end)
+		}
+	}
+	if disabled {
+		gtx = gtx.Disabled()
+	}
+	return btn.Layout(gtx, func(gtx Gtx) Dim {
+		size := gtx.Constraints.Min
+		bgColor := s.CellBg
+		if disabled || !c.isWorkingTime(start, offset) {
+			bgColor = s.OutOfMonthBg
+		}
+		if btn.Hovered() && !disabled {
+			bgColor = s.RangeBg
+		}
+		paint.FillShape(gtx.Ops, bgColor, clip.Rect{Max: size}.Op())
+		lineWidth := gtx.Dp(1)
+		lineColor := s.SlotLine
+		if offset%time.Hour != 0 {
+			lineColor.A /= 2
+		}
+		paint.FillShape(gtx.Ops, lineColor, clip.Rect{Max: image.Point{X: size.X, Y: lineWidth}}.Op())
+		paint.FillShape(gtx.Ops, s.SlotLine, clip.Rect{Max: image.Point{X: lineWidth, Y: size.Y}}.Op())
+		if !now.Before(start) && now.Before(end) {
+			y := int(float32(size.Y) * float32(now.Sub(start)) / float32(end.Sub(start)))
+			line := image.Rectangle{Min: image.Point{Y: y}, Max: image.Point{X: size.X, Y: y + gtx.Dp(2)}}
+			paint.FillShape(gtx.Ops, s.NowLine, clip.Rect(line).Op())
+		}
+		return Dim{Size: size}
+	})
+}
+
+// timelineSegment is the part of a timed event drawn in a day column of the week and day views.
+type timelineSegment struct {
+	event Event
+	day   int
+	// start and end are the wall clock offsets from midnight of the day
+	start, end time.Duration
+	// lane is the column of the segment among the lanes of its day
+	lane, lanes int
+}
+
+// layoutTimelineEvents splits the timed events into the days consecutive days starting on the
+// midnight first, the overlapping segments of a day are placed side by side in lanes.
+func layoutTimelineEvents(events []Event, first time.Time, days int) []timelineSegment {
+	var segments []timelineSegment
+	for _, e := range events {
+		if e.AllDay {
+			continue
+		}
+		for day := 0; day < days; day++ {
+			dayStart, dayEnd := first.AddDate(0, 0, day), first.AddDate(0, 0, day+1)
+			if !e.overlaps(dayStart, dayEnd) {
+				continue
+			}
+			offset := func(t time.Time) time.Duration {
+				switch {
+				case t.Before(dayStart):
+					return 0
+				case !t.Before(dayEnd):
+					return 24 * time.Hour
+				}
+				hour, minute, second := t.In(first.Location()).Clock()
+				return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute + time.Duration(second)*time.Second
+			}
+			segment := timelineSegment{event: e, day: day, start: offset(e.Start), end: offset(e.End)}
+			if segment.end < segment.start {
+				segment.end = segment.start
+			}
+			segments = append(segments, segment)
+		}
+	}
+	sort.SliceStable(segments, func(i, j int) bool {
+		a, b := segments[i], segments[j]
+		if a.day != b.day {
+			return a.day < b.day
+		}
+		if a.start != b.start {
+			return a.start < b.start
+		}
+		return a.end > b.end
+	})
+	lanes := make([]int, days)
+	var laneEnds []time.Duration
+	for i := range segments {
+		if i == 0 || segments[i].day != segments[i-1].day {
+			laneEnds = laneEnds[:0]
+		}
+		lane := 0
+		for ; lane < len(laneEnds); lane++ {
+			if laneEnds[lane] <= segments[i].start {
+				break
+			}
+		}
+		if lane == len(laneEnds) {
+			laneEnds = append(laneEnds, 0)
+		}
+		laneEnds[lane] = segments[i].end
+		if segments[i].end == segments[i].start {
+			// an event without a duration still occupies its instant
+			laneEnds[lane]++
+		}
+		segments[i].lane = lane
+		if lane+1 > lanes[segments[i].day] {
+			lanes[segments[i].day] = lane + 1
+		}
+	}
+	for i := range segments {
+		segments[i].lanes = lanes[segments[i].day]
+	}
+	return segments
+}
+
+// drawTimelineEvents draws the timed events of the days consecutive days starting on the midnight
+// first over the time slots, scrolled with them within size.
+func (s *CalendarStyle) drawTimelineEvents(gtx Gtx, events []Event, first time.Time, days, axisWidth, columnWidth int, size image.Point) {
+	c := s.Calendar
+	slotHeight := gtx.Dp(s.SlotHeight)
+	slotLength := c.slotLength()
+	top := -(c.slotList.Position.First*slotHeight + c.slotList.Position.Offset)
+	y := func(offset time.Duration) int {
+		return top + int(int64(slotHeight)*int64(offset)/int64(slotLength))
+	}
+	defer clip.Rect{Max: size}.Push(gtx.Ops).Pop()
+	if moved, ok := c.movedEvent(); ok {
+		// highlights the slots the dragged event is dropped on
+		for _, segment := range layoutTimelineEvents([]Event{moved}, first, days) {
+			x := axisWidth + segment.day*columnWidth
+			rect := image.Rect(x, y(segment.start), x+columnWidth, y(segment.end))
+			if minHeight := gtx.Dp(s.EventHeight); rect.Dy() < minHeight {
+				rect.Max.Y = rect.Min.Y + minHeight
+			}
+			paint.FillShape(gtx.Ops, s.DropTargetBg, clip.Rect(rect).Op())
+		}
+	}
+	segments := layoutTimelineEvents(events, first, days)
+	c.timelineChips.frame()
+	for _, segment := range segments {
+		laneWidth := columnWidth / segment.lanes
+		x := segment.day*columnWidth + segment.lane*laneWidth
+		offset := image.Point{X: axisWidth + x, Y: y(segment.start)}
+		height := y(segment.end) - offset.Y
+		if minHeight := gtx.Dp(s.EventHeight); height < minHeight {
+			height = minHeight
+		}
+		event := segment.event
+		target := func(press, pos f32.Point) time.Time {
+			// the days under the pointer when pressed and now, and the slots it moved by
+			pressDay := floorDiv(float32(x)+press.X, columnWidth)
+			day := clampInt(floorDiv(float32(x)+pos.X, columnWidth), 0, days-1)
+			slots := int(math.Round(float64((pos.Y - press.Y) / float32(slotHeight))))
+			start := event.Start.In(first.Location())
+			year, month, date := start.Date()
+			hour, minute, second := start.Clock()
+			minute += slots * int(slotLength/time.Minute)
+			return time.Date(year, month, date+day-pressDay, hour, minute, second, start.Nanosecond(), start.Location())
+		}
+		stack := op.Offset(offset).Push(gtx.Ops)
+		chip, key := c.timelineChips.chip(event)
+		s.drawEventBar(gtx, chip, key, event, image.Point{X: laneWidth, Y: height}, target)
+		stack.Pop()
+	}
+}
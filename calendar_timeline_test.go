@@ -0,0 +1,46 @@
+package giowidgets
+
+import (
+	"gioui.org/font/gofont"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/widget/material"
+	"image"
+	"testing"
+	"time"
+)
+
+func TestAllDayEventsInTimeline(t *testing.T) {
+	th := material.NewTheme(gofont.Collection())
+	day := func(d int) time.Time {
+		return time.Date(2024, time.March, d, 0, 0, 0, 0, time.UTC)
+	}
+	events := EventList{
+		{ID: "trip", Start: day(12), End: day(15), AllDay: true},
+		{ID: "holiday", Start: day(13), End: day(14), AllDay: true},
+		{ID: "meeting", Start: day(13).Add(9 * time.Hour), End: day(13).Add(10 * time.Hour)},
+	}
+	tests := []struct {
+		view   CalendarView
+		allDay int
+	}{
+		{CalendarWeekView, 2},
+		{CalendarDayView, 2},
+	}
+	for _, test := range tests {
+		c := Calendar{View: test.view, EventSource: events, Location: time.UTC}
+		c.SetTime(day(13))
+		var ops op.Ops
+		NewCalendarStyle(th, &c).Layout(layout.Context{Ops: &ops, Constraints: layout.Exact(image.Point{X: 800, Y: 600})})
+		if got := len(c.allDayChips.drawn); got != test.allDay {
+			t.Errorf("view %d: %d all-day bars, want %d", test.view, got, test.allDay)
+		}
+		if got := len(c.timelineChips.drawn); got != 1 {
+			t.Errorf("view %d: %d timed bars, want 1", test.view, got)
+		}
+	}
+	segments := layoutRowEvents(events[:2], day(13), 1)
+	if len(segments) != 2 || segments[0].last != 0 || segments[1].lane != 1 {
+		t.Errorf("day view segments %+v, want the trip and the holiday in two lanes of one day", segments)
+	}
+}
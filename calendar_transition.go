@@ -0,0 +1,116 @@
+package giowidgets
+
+import (
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"image"
+	"image/color"
+	"time"
+)
+
+// CalendarTransition animates the month grids when Time moves to another month.
+type CalendarTransition int
+
+const (
+	// TransitionNone swaps the grids instantly.
+	TransitionNone CalendarTransition = iota
+	// TransitionSlide slides the new grids in from the side of the navigation, forward from the right.
+	TransitionSlide
+	// TransitionFade cross-fades the old and the new grids. A CellRenderer draws its cells without fading.
+	TransitionFade
+)
+
+const defaultTransitionDuration = 250 * time.Millisecond
+
+// animates reports whether month changes are animated.
+func (c *Calendar) animates() bool {
+	return c.Transition != TransitionNone && !c.ReducedMotion
+}
+
+// transitionProgress returns the eased progress of the running transition, 1 when there is none.
+func (c *Calendar) transitionProgress(gtx Gtx) float32 {
+	if c.transitionStart.IsZero() || !c.animates() {
+		return 1
+	}
+	duration := c.TransitionDuration
+	if duration <= 0 {
+		duration = defaultTransitionDuration
+	}
+	progress := float32(gtx.Now.Sub(c.transitionStart)) / float32(duration)
+	if progress >= 1 {
+		c.transitionStart = time.Time{}
+		return 1
+	}
+	// ease out cubic
+	progress = 1 - progress
+	return 1 - progress*progress*progress
+}
+
+// drawMonthsTransition draws the month grids, animating them when Time moved to another month
+// since the last frame.
+func (s *CalendarStyle) drawMonthsTransition(gtx Gtx) Dim {
+	c := s.Calendar
+	if !c.shownTime.IsZero() && c.animates() {
+		if months := monthsBetween(c.system(), c.shownTime, c.Time()); months != 0 {
+			c.transitionFrom, c.transitionStart = c.shownTime, gtx.Now
+			c.transitionDirection = 1
+			if months < 0 {
+				c.transitionDirection = -1
+			}
+		}
+	}
+	c.shownTime = c.Time()
+	progress := c.transitionProgress(gtx)
+	if progress >= 1 {
+		return s.drawMonths(gtx)
+	}
+	op.InvalidateOp{}.Add(gtx.Ops)
+	oldStyle, newStyle := s, s
+	if c.Transition == TransitionFade {
+		oldFaded, newFaded := s.faded(1-progress), s.faded(progress)
+		oldStyle, newStyle = &oldFaded, &newFaded
+	}
+
+	// the old grids are drawn first, the new ones then find the hovered day
+	current, views := c.time, c.monthViews
+	c.time, c.monthViews = c.transitionFrom, c.transitionViews
+	macro := op.Record(gtx.Ops)
+	oldStyle.drawMonths(gtx.Disabled())
+	oldCall := macro.Stop()
+	c.transitionViews = c.monthViews
+	c.time, c.monthViews = current, views
+
+	macro = op.Record(gtx.Ops)
+	d := newStyle.drawMonths(gtx)
+	newCall := macro.Stop()
+
+	defer clip.Rect{Max: d.Size}.Push(gtx.Ops).Pop()
+	var oldOffset, newOffset int
+	if c.Transition == TransitionSlide {
+		oldOffset = -int(float32(c.transitionDirection*d.Size.X) * progress)
+		newOffset = c.transitionDirection*d.Size.X + oldOffset
+	}
+	stack := op.Offset(image.Point{X: oldOffset}).Push(gtx.Ops)
+	oldCall.Add(gtx.Ops)
+	stack.Pop()
+	stack = op.Offset(image.Point{X: newOffset}).Push(gtx.Ops)
+	newCall.Add(gtx.Ops)
+	stack.Pop()
+	return d
+}
+
+// faded returns the style with the alpha of its colors scaled by alpha.
+func (s CalendarStyle) faded(alpha float32) CalendarStyle {
+	colors := []*color.NRGBA{
+		&s.CellBg, &s.CellFg, &s.OutOfMonthBg, &s.OutOfMonthFg, &s.WeekendFg, &s.DisabledFg,
+		&s.TodayBg, &s.TodayFg, &s.SelectedBg, &s.SelectedFg, &s.HoverBg, &s.HoverFg, &s.RangeBg, &s.RangeOutsideBg,
+		&s.FocusRing, &s.HeaderBg, &s.HeaderFg, &s.WeekNumberBg, &s.WeekNumberFg, &s.TitleFg,
+		&s.BadgeBg, &s.BadgeFg, &s.EventBg, &s.EventFg, &s.MoreEventsFg, &s.DropTargetBg,
+		&s.HolidayBg, &s.HolidayFg, &s.TooltipBg, &s.TooltipFg,
+		&s.SlotLine, &s.NowLine,
+	}
+	for _, c := range colors {
+		c.A = uint8(float32(c.A) * alpha)
+	}
+	return s
+}
@@ -0,0 +1,69 @@
+package giowidgets
+
+import (
+	"fmt"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/text"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+	"time"
+)
+
+type OnWeekClick func(start, end time.Time)
+
+// WeekNumbering selects how the week numbers column counts the weeks.
+type WeekNumbering int
+
+const (
+	// ISOWeekNumbering numbers the weeks as ISO 8601 does, see time.Time.ISOWeek.
+	ISOWeekNumbering WeekNumbering = iota
+	// USWeekNumbering counts week 1 as the week containing January 1st, starting the weeks on FirstDayOfWeek.
+	USWeekNumbering
+)
+
+// columnsCount returns the number of columns of the grid, including the week numbers column.
+func (c *Calendar) columnsCount() int {
+	if c.ShowWeekNumbers {
+		return 8
+	}
+	return 7
+}
+
+// drawWeekNumberColumn draws the number of the week starting on rowStart, btn reports its clicks.
+func (s *CalendarStyle) drawWeekNumberColumn(gtx Gtx, rowStart time.Time, btn *widget.Clickable) FlexChild {
+	c := s.Calendar
+	return layout.Rigid(func(gtx Gtx) Dim {
+		if btn.Clicked() && c.OnWeekClick != nil {
+			c.OnWeekClick(rowStart, rowStart.AddDate(0, 0, 6))
+		}
+		bgColor := s.WeekNumberBg
+		if btn.Hovered() && c.OnWeekClick != nil {
+			bgColor = s.RangeBg
+		}
+		txtColor := s.WeekNumberFg
+		week := c.weekModel().weekNumber(rowStart, c.WeekNumbering)
+		return btn.Layout(gtx, func(gtx Gtx) Dim {
+			gtx.Constraints.Min, gtx.Constraints.Max = c.cellSize, c.cellSize
+			mac := op.Record(gtx.Ops)
+			d := s.CellInset.Layout(gtx, func(gtx Gtx) Dim {
+				return layout.N.Layout(gtx, func(gtx Gtx) Dim {
+					label := material.Label(s.Theme, s.TextSize, fmt.Sprintf("%d", week))
+					label.MaxLines = 1
+					label.Color = txtColor
+					label.Alignment = text.Middle
+					if s.compact(gtx) {
+						label.TextSize = s.CompactTextSize
+					}
+					return label.Layout(gtx)
+				})
+			})
+			call := mac.Stop()
+			paint.FillShape(gtx.Ops, bgColor, clip.Rect{Max: d.Size}.Op())
+			call.Add(gtx.Ops)
+			return d
+		})
+	})
+}
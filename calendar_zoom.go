@@ -0,0 +1,322 @@
+package giowidgets
+
+import (
+	"fmt"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/text"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+	"golang.org/x/exp/shiny/materialdesign/icons"
+	"image"
+	"time"
+)
+
+// CalendarView is the zoom level of the Calendar body.
+type CalendarView int
+
+const (
+	// CalendarMonthView displays the days of the months.
+	CalendarMonthView CalendarView = iota
+	// CalendarYearView displays the months of the year of Time.
+	CalendarYearView
+	// CalendarDecadeView displays the years of the decade of Time.
+	CalendarDecadeView
+	// CalendarWeekView displays the time slots of the week of Time, one column per day.
+	CalendarWeekView
+	// CalendarDayView displays the time slots of the day of Time.
+	CalendarDayView
+)
+
+// zoomColumns is the number of columns of the year and decade grids.
+const zoomColumns = 3
+
+// decadeItems is the number of years of the decade grid, the decade is surrounded by a year on each side.
+const decadeItems = 12
+
+// ZoomOut displays the next coarser view, from the day view through the week, month
+// and year views to the decade view.
+func (c *Calendar) ZoomOut() {
+	switch c.View {
+	case CalendarDayView:
+		c.View = CalendarWeekView
+	case CalendarWeekView:
+		c.View = CalendarMonthView
+	case CalendarMonthView:
+		c.View = CalendarYearView
+	case CalendarYearView:
+		c.View = CalendarDecadeView
+	}
+}
+
+// ZoomIn displays the next finer view, from the decade view down to the month view.
+func (c *Calendar) ZoomIn() {
+	switch c.View {
+	case CalendarDecadeView:
+		c.View = CalendarYearView
+	case CalendarYearView:
+		c.View = CalendarMonthView
+	}
+}
+
+// decadeStart returns the first year of the decade containing year.
+func decadeStart(year int) int {
+	return year - (year%10+10)%10
+}
+
+// isYearDisabled reports whether every day of the year lies outside of MinDate and MaxDate.
+func (c *Calendar) isYearDisabled(year int) bool {
+	if !c.MinDate.IsZero() && year < c.date(c.MinDate).Year {
+		return true
+	}
+	return !c.MaxDate.IsZero() && year > c.date(c.MaxDate).Year
+}
+
+// stepTime returns Time moved by step units of the current view, months, years or decades.
+func (c *Calendar) stepTime(step int) time.Time {
+	switch c.View {
+	case CalendarYearView:
+		return addYears(c.system(), c.Time(), step)
+	case CalendarDecadeView:
+		return addYears(c.system(), c.Time(), step*10)
+	case CalendarWeekView:
+		return c.Time().AddDate(0, 0, step*7)
+	case CalendarDayView:
+		return c.Time().AddDate(0, 0, step)
+	}
+	return addMonths(c.system(), c.Time(), step)
+}
+
+// isStepDisabled reports whether the view reached by moving step units contains no enabled day.
+func (c *Calendar) isStepDisabled(step int) bool {
+	t := c.stepTime(step)
+	switch c.View {
+	case CalendarYearView:
+		return c.isYearDisabled(c.date(t).Year)
+	case CalendarDecadeView:
+		start := decadeStart(c.date(t).Year)
+		return c.isYearDisabled(start) && c.isYearDisabled(start+9)
+	case CalendarWeekView:
+		start := c.weekModel().weekStart(t)
+		return c.isOutOfBounds(start, start.AddDate(0, 0, 6))
+	case CalendarDayView:
+		return c.isOutOfBounds(t, t)
+	}
+	return c.isMonthDisabled(t)
+}
+
+// step moves Time by step units of the current view.
+func (c *Calendar) step(step int) {
+	c.SetTime(c.clampTime(c.stepTime(step)))
+}
+
+// zoomTitle returns the title of the header button zooming out of the current view.
+func (c *Calendar) zoomTitle() string {
+	date := c.date(c.Time())
+	switch c.View {
+	case CalendarYearView:
+		return fmt.Sprintf("%d", date.Year)
+	case CalendarDecadeView:
+		start := decadeStart(date.Year)
+		return fmt.Sprintf("%d – %d", start, start+9)
+	case CalendarWeekView:
+		start := c.weekModel().weekStart(c.Time())
+		return fmt.Sprintf("%s – %s", c.formatDate(start), c.formatDate(start.AddDate(0, 0, 6)))
+	case CalendarDayView:
+		return fmt.Sprintf("%s %s", c.resolvedLocale().WeekdayNames[c.Time().Weekday()], c.formatDate(c.Time()))
+	}
+	return fmt.Sprintf("%s %d", c.system().MonthName(date.Month, c.resolvedLocale()), date.Year)
+}
+
+// formatDate writes the day t with the DateLayout of the Locale, or as the day, the month name and
+// the year of the CalendarSystem when it is not Gregorian.
+func (c *Calendar) formatDate(t time.Time) string {
+	if c.system() == Gregorian {
+		return c.resolvedLocale().FormatDate(t)
+	}
+	date := c.date(t)
+	return fmt.Sprintf("%d %s %d", date.Day, c.system().MonthName(date.Month, c.resolvedLocale()), date.Year)
+}
+
+// drawZoomTitle draws the header button zooming out of the current view.
+func (s *CalendarStyle) drawZoomTitle(gtx Gtx) Dim {
+	c := s.Calendar
+	if c.btnZoomOut.Clicked() {
+		c.ZoomOut()
+	}
+	if c.View == CalendarDecadeView {
+		gtx = gtx.Disabled()
+	}
+	return c.btnZoomOut.Layout(gtx, func(gtx Gtx) Dim {
+		flex := Flex{Alignment: layout.Middle}
+		return flex.Layout(gtx,
+			layout.Rigid(func(gtx Gtx) Dim {
+				label := material.Label(s.Theme, s.TextSize, c.zoomTitle())
+				label.Color = s.TitleFg
+				label.MaxLines = 1
+				return label.Layout(gtx)
+			}),
+			layout.Rigid(layout.Spacer{Width: unit.Dp(8)}.Layout),
+			layout.Rigid(func(gtx Gtx) Dim {
+				if c.View == CalendarDecadeView {
+					return Dim{}
+				}
+				downIcon, _ := widget.NewIcon(icons.NavigationArrowDropDown)
+				return downIcon.Layout(gtx, s.IconColor)
+			}),
+		)
+	})
+}
+
+// drawBody draws the grids of the current view.
+func (s *CalendarStyle) drawBody(gtx Gtx) Dim {
+	c := s.Calendar
+	switch c.View {
+	case CalendarYearView:
+		c.shownTime = c.Time()
+		return s.drawYearGrid(gtx)
+	case CalendarDecadeView:
+		c.shownTime = c.Time()
+		return s.drawDecadeGrid(gtx)
+	case CalendarWeekView:
+		c.shownTime = c.Time()
+		return s.drawTimeline(gtx, c.weekModel().weekStart(c.Time()), 7)
+	case CalendarDayView:
+		c.shownTime = c.Time()
+		return s.drawTimeline(gtx, c.Time(), 1)
+	}
+	return s.drawMonthsTransition(gtx)
+}
+
+// zoomItem describes an item of the year and decade grids.
+type zoomItem struct {
+	label    string
+	selected bool
+	// outside is set for the years surrounding the decade
+	outside  bool
+	disabled bool
+}
+
+// drawYearGrid draws the months of the year of Time, picking one displays its days.
+func (s *CalendarStyle) drawYearGrid(gtx Gtx) Dim {
+	c := s.Calendar
+	date := c.date(c.Time())
+	months := c.system().MonthsInYear(date.Year)
+	items := make([]zoomItem, months)
+	for i := range items {
+		month := CalendarDate{Year: date.Year, Month: i + 1, Day: 1}
+		items[i] = zoomItem{
+			label:    c.system().MonthName(i+1, c.resolvedLocale()),
+			selected: i+1 == date.Month,
+			disabled: c.isMonthDisabled(c.system().ToTime(month, c.Time().Location())),
+		}
+	}
+	return s.drawZoomGrid(gtx, items, func(index int) {
+		date.Month = index + 1
+		c.setDate(date)
+		c.ZoomIn()
+	})
+}
+
+// drawDecadeGrid draws the years of the decade of Time, picking one displays its months.
+func (s *CalendarStyle) drawDecadeGrid(gtx Gtx) Dim {
+	c := s.Calendar
+	date := c.date(c.Time())
+	first := decadeStart(date.Year) - 1
+	items := make([]zoomItem, decadeItems)
+	for i := range items {
+		year := first + i
+		items[i] = zoomItem{
+			label:    fmt.Sprintf("%d", year),
+			selected: year == date.Year,
+			outside:  i == 0 || i == decadeItems-1,
+			disabled: c.isYearDisabled(year),
+		}
+	}
+	return s.drawZoomGrid(gtx, items, func(index int) {
+		date.Year = first + index
+		c.setDate(date)
+		c.ZoomIn()
+	})
+}
+
+// drawZoomGrid lays out items in rows of zoomColumns, onPick is called with the index of a clicked item.
+func (s *CalendarStyle) drawZoomGrid(gtx Gtx, items []zoomItem, onPick func(index int)) Dim {
+	c := s.Calendar
+	for len(c.zoomButtons) < len(items) {
+		c.zoomButtons = append(c.zoomButtons, widget.Clickable{})
+	}
+	width := c.gridWidth / zoomColumns
+	height := s.clampCellSize(gtx, gtx.Constraints.Max.Y/((decadeItems+zoomColumns-1)/zoomColumns))
+	var rows []FlexChild
+	for start := 0; start < len(items); start += zoomColumns {
+		start := start
+		rows = append(rows, layout.Rigid(func(gtx Gtx) Dim {
+			var children []FlexChild
+			for i := start; i < start+zoomColumns && i < len(items); i++ {
+				i := i
+				children = append(children, layout.Rigid(func(gtx Gtx) Dim {
+					btn := &c.zoomButtons[i]
+					item := items[i]
+					if btn.Clicked() && !item.disabled {
+						c.requestFocus = true
+						onPick(i)
+						op.InvalidateOp{}.Add(gtx.Ops)
+					}
+					if item.disabled {
+						gtx = gtx.Disabled()
+					}
+					return btn.Layout(gtx, func(gtx Gtx) Dim {
+						gtx.Constraints.Min = image.Point{X: width, Y: height}
+						gtx.Constraints.Max = gtx.Constraints.Min
+						return s.drawZoomItem(gtx, item, btn.Hovered())
+					})
+				}))
+			}
+			flex := Flex{}
+			return flex.Layout(gtx, children...)
+		}))
+	}
+	flex := Flex{Axis: layout.Vertical}
+	return flex.Layout(gtx, rows...)
+}
+
+func (s *CalendarStyle) drawZoomItem(gtx Gtx, item zoomItem, hovered bool) Dim {
+	c := s.Calendar
+	bgColor := s.CellBg
+	txtColor := s.CellFg
+	if item.outside {
+		bgColor = s.OutOfMonthBg
+		txtColor = s.OutOfMonthFg
+	}
+	if item.disabled {
+		txtColor = s.DisabledFg
+	} else if item.selected {
+		bgColor = s.SelectedBg
+		txtColor = s.SelectedFg
+	} else if hovered {
+		bgColor = s.HoverBg
+		txtColor = s.HoverFg
+	}
+	size := gtx.Constraints.Min
+	paint.FillShape(gtx.Ops, bgColor, clip.Rect{Max: size}.Op())
+	s.CellInset.Layout(gtx, func(gtx Gtx) Dim {
+		return layout.Center.Layout(gtx, func(gtx Gtx) Dim {
+			label := material.Label(s.Theme, s.TextSize, item.label)
+			label.Color = txtColor
+			label.MaxLines = 1
+			label.Alignment = text.Middle
+			return label.Layout(gtx)
+		})
+	})
+	if item.selected && c.focused {
+		focusRing := widget.Border{Color: s.FocusRing, Width: unit.Dp(2)}
+		focusRing.Layout(gtx, func(gtx Gtx) Dim {
+			return Dim{Size: size}
+		})
+	}
+	return Dim{Size: size}
+}
@@ -0,0 +1,291 @@
+package giowidgets
+
+import (
+	"errors"
+	"fmt"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+	"golang.org/x/exp/shiny/materialdesign/icons"
+	"image"
+	"image/color"
+	"strings"
+	"time"
+)
+
+// OnDateChange is called with the date typed or picked in a DateField, the zero time when it is cleared.
+type OnDateChange func(t time.Time)
+
+const defaultDateFormat = "2006-01-02"
+
+// DateField is a text input for a date, the date is typed following Format or picked in a Calendar
+// opened from the trailing icon. It holds the state and is drawn by a DateFieldStyle.
+type DateField struct {
+	// Format parses and formats the text, "2006-01-02" when empty.
+	Format string
+	Editor widget.Editor
+	// Calendar is opened below the field, its MinDate, MaxDate and IsDateDisabled also validate the
+	// typed dates. Its OnCalendarDateClick is set by the DateField.
+	Calendar Calendar
+	OnDateChange
+	date time.Time
+	err  error
+	// edited is set while the text was changed since the date was last validated
+	edited        bool
+	initialized   bool
+	editorFocused bool
+	btnCalendar   widget.Clickable
+	showCalendar  bool
+}
+
+// Date returns the last valid date of the field, the zero time when it is empty. The typed text is
+// validated when it is submitted or loses the focus.
+func (f *DateField) Date() time.Time {
+	return f.date
+}
+
+// SetDate writes t to the field, the zero time clears it.
+func (f *DateField) SetDate(t time.Time) {
+	f.date, f.err, f.edited = t, nil, false
+	if t.IsZero() {
+		f.Editor.SetText("")
+	} else {
+		f.Editor.SetText(t.Format(f.format()))
+	}
+	f.selectDate(t)
+}
+
+// Err returns the reason why the validated text of the field is not a valid date, nil when it is
+// or when it was changed since.
+func (f *DateField) Err() error {
+	return f.err
+}
+
+// ShowCalendar opens or closes the Calendar below the field.
+func (f *DateField) ShowCalendar(show bool) {
+	f.showCalendar = show
+	if show && !f.date.IsZero() {
+		f.Calendar.SetTime(f.date)
+	}
+}
+
+func (f *DateField) format() string {
+	if f.Format == "" {
+		return defaultDateFormat
+	}
+	return f.Format
+}
+
+// parse reads the date of text in the location of the Calendar and checks it against its bounds, the
+// errors are written in the language of its Locale.
+func (f *DateField) parse(text string) (time.Time, error) {
+	texts := f.Calendar.resolvedLocale().dateFieldTexts()
+	t, err := time.ParseInLocation(f.format(), strings.TrimSpace(text), f.Calendar.location())
+	if err != nil {
+		example := time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC).Format(f.format())
+		return t, fmt.Errorf(texts.Invalid, example)
+	}
+	c := &f.Calendar
+	switch {
+	case !c.MinDate.IsZero() && compareDays(t, c.MinDate) < 0:
+		return t, fmt.Errorf(texts.Before, c.MinDate.Format(f.format()))
+	case !c.MaxDate.IsZero() && compareDays(t, c.MaxDate) > 0:
+		return t, fmt.Errorf(texts.After, c.MaxDate.Format(f.format()))
+	case c.IsDateDisabled != nil && c.IsDateDisabled(t):
+		return t, errors.New(texts.Unavailable)
+	}
+	return t, nil
+}
+
+// setDate records the valid date t, calling OnDateChange when it differs from the previous one.
+func (f *DateField) setDate(t time.Time) {
+	changed := !t.Equal(f.date)
+	f.date, f.err, f.edited = t, nil, false
+	if changed && f.OnDateChange != nil {
+		f.OnDateChange(t)
+	}
+}
+
+// selectDate shows t as the only day selected in the Calendar, the zero time clearing its selection.
+func (f *DateField) selectDate(t time.Time) {
+	f.Calendar.ClearSelection()
+	if !t.IsZero() {
+		f.Calendar.SetTime(t)
+		f.Calendar.Select(t)
+	}
+}
+
+// validate reads the typed text, recording its date or the reason why it isn't valid.
+func (f *DateField) validate() {
+	text := f.Editor.Text()
+	if strings.TrimSpace(text) == "" {
+		f.setDate(time.Time{})
+		f.selectDate(time.Time{})
+		return
+	}
+	t, err := f.parse(text)
+	if err != nil {
+		f.err, f.edited = err, false
+		return
+	}
+	// normalizes the text of a valid date
+	f.Editor.SetText(t.Format(f.format()))
+	f.setDate(t)
+	f.selectDate(t)
+}
+
+// update handles the edits of the text, the clicks of the icon and the days picked in the Calendar.
+func (f *DateField) update() {
+	if !f.initialized {
+		f.Editor.SingleLine = true
+		f.Editor.Submit = true
+		// the Calendar selects the clicked day itself
+		f.Calendar.OnCalendarDateClick = func(t time.Time) {
+			f.Editor.SetText(t.Format(f.format()))
+			f.showCalendar = false
+			f.setDate(t)
+		}
+		f.initialized = true
+	}
+	for _, e := range f.Editor.Events() {
+		switch e.(type) {
+		case widget.ChangeEvent:
+			// the text is validated once submitted, until then it is no longer wrong
+			f.err, f.edited = nil, true
+		case widget.SubmitEvent:
+			f.validate()
+		}
+	}
+	if f.btnCalendar.Clicked() {
+		f.ShowCalendar(!f.showCalendar)
+	}
+	// focusing the text closes the Calendar, leaving it validates the text
+	if focused := f.Editor.Focused(); focused != f.editorFocused {
+		f.editorFocused = focused
+		if focused {
+			f.showCalendar = false
+		} else if f.edited {
+			f.validate()
+		}
+	}
+}
+
+// DateFieldStyle draws a DateField. It is built by NewDateFieldStyle and can be adjusted before
+// calling Layout.
+type DateFieldStyle struct {
+	DateField *DateField
+	Theme     *material.Theme
+	// Hint is displayed while the field is empty. When empty, it is the Format of the field written
+	// with the letters of the Locale of the Calendar, like YYYY-MM-DD.
+	Hint     string
+	TextSize unit.Sp
+	Fg       color.NRGBA
+	HintFg   color.NRGBA
+	Border   color.NRGBA
+	// ErrorColor colors the border and the message of a field holding an invalid date.
+	ErrorColor color.NRGBA
+	IconColor  color.NRGBA
+	Inset      layout.Inset
+	// Calendar draws the Calendar opened below the field within CalendarWidth and CalendarHeight,
+	// over CalendarBg.
+	Calendar       CalendarStyle
+	CalendarBg     color.NRGBA
+	CalendarWidth  unit.Dp
+	CalendarHeight unit.Dp
+}
+
+// NewDateFieldStyle returns the style drawing field with the colors of th.
+func NewDateFieldStyle(th *material.Theme, field *DateField) DateFieldStyle {
+	hintFg := th.Fg
+	hintFg.A = 120
+	return DateFieldStyle{
+		DateField:      field,
+		Theme:          th,
+		TextSize:       th.TextSize,
+		Fg:             th.Fg,
+		HintFg:         hintFg,
+		Border:         th.Fg,
+		ErrorColor:     color.NRGBA{R: 0xb0, G: 0x00, B: 0x20, A: 0xff},
+		IconColor:      th.ContrastBg,
+		Inset:          layout.Inset{Top: 8, Bottom: 8, Left: 12, Right: 4},
+		Calendar:       NewCalendarStyle(th, &field.Calendar),
+		CalendarBg:     th.Bg,
+		CalendarWidth:  unit.Dp(320),
+		CalendarHeight: unit.Dp(360),
+	}
+}
+
+func (s DateFieldStyle) Layout(gtx Gtx) Dim {
+	f := s.DateField
+	f.update()
+	borderColor := s.Border
+	if f.err != nil {
+		borderColor = s.ErrorColor
+	}
+	var fieldHeight int
+	flex := Flex{Axis: layout.Vertical}
+	d := flex.Layout(gtx,
+		layout.Rigid(func(gtx Gtx) Dim {
+			border := widget.Border{Color: borderColor, CornerRadius: unit.Dp(4), Width: unit.Dp(1)}
+			d := border.Layout(gtx, func(gtx Gtx) Dim {
+				flex := Flex{Alignment: layout.Middle}
+				return flex.Layout(gtx,
+					layout.Flexed(1, func(gtx Gtx) Dim {
+						return s.Inset.Layout(gtx, func(gtx Gtx) Dim {
+							hint := s.Hint
+							if hint == "" {
+								hint = f.Calendar.resolvedLocale().dateFieldTexts().hint(f.format())
+							}
+							editor := material.Editor(s.Theme, &f.Editor, hint)
+							editor.TextSize = s.TextSize
+							editor.Color = s.Fg
+							editor.HintColor = s.HintFg
+							return editor.Layout(gtx)
+						})
+					}),
+					layout.Rigid(func(gtx Gtx) Dim {
+						return f.btnCalendar.Layout(gtx, func(gtx Gtx) Dim {
+							inset := layout.UniformInset(unit.Dp(8))
+							return inset.Layout(gtx, func(gtx Gtx) Dim {
+								size := gtx.Dp(unit.Dp(24))
+								gtx.Constraints.Min = image.Point{X: size, Y: size}
+								gtx.Constraints.Max = gtx.Constraints.Min
+								icon, _ := widget.NewIcon(icons.ActionDateRange)
+								return icon.Layout(gtx, s.IconColor)
+							})
+						})
+					}),
+				)
+			})
+			fieldHeight = d.Size.Y
+			return d
+		}),
+		layout.Rigid(func(gtx Gtx) Dim {
+			if f.err == nil {
+				return Dim{}
+			}
+			inset := Inset{Top: 4, Left: 12}
+			return inset.Layout(gtx, func(gtx Gtx) Dim {
+				label := material.Label(s.Theme, s.TextSize*0.85, f.err.Error())
+				label.Color = s.ErrorColor
+				return label.Layout(gtx)
+			})
+		}),
+	)
+	if f.showCalendar {
+		// the Calendar is drawn over the widgets laid out after the field
+		macro := op.Record(gtx.Ops)
+		op.Offset(image.Point{Y: fieldHeight}).Add(gtx.Ops)
+		gtx := gtx
+		gtx.Constraints = layout.Exact(image.Point{X: gtx.Dp(s.CalendarWidth), Y: gtx.Dp(s.CalendarHeight)})
+		paint.FillShape(gtx.Ops, s.CalendarBg, clip.Rect{Max: gtx.Constraints.Max}.Op())
+		s.Calendar.Calendar = &f.Calendar
+		s.Calendar.Layout(gtx)
+		op.Defer(gtx.Ops, macro.Stop())
+	}
+	return d
+}
@@ -0,0 +1,65 @@
+package giowidgets
+
+import (
+	"gioui.org/font/gofont"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/widget/material"
+	"image"
+	"testing"
+	"time"
+)
+
+func TestCalendarSelect(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, time.March, d, 0, 0, 0, 0, time.UTC)
+	}
+	c := Calendar{MinDate: day(5)}
+	if c.Select(day(4)) || len(c.Selected()) != 0 {
+		t.Errorf("selected a day before MinDate: %v", c.Selected())
+	}
+	if !c.Select(day(6)) || !c.Select(day(7)) || len(c.Selected()) != 1 || !c.Selected()[0].Equal(day(7)) {
+		t.Errorf("SelectionSingle: got %v, want March 7", c.Selected())
+	}
+	var start, end time.Time
+	c = Calendar{SelectionMode: SelectionRange, OnRangeSelected: func(s, e time.Time) { start, end = s, e }}
+	c.Select(day(12))
+	c.Select(day(8))
+	if !start.Equal(day(8)) || !end.Equal(day(12)) {
+		t.Errorf("OnRangeSelected(%v, %v), want March 8 to 12", start, end)
+	}
+}
+
+func TestDateFieldValidation(t *testing.T) {
+	th := material.NewTheme(gofont.Collection())
+	var changes []time.Time
+	f := &DateField{OnDateChange: func(t time.Time) { changes = append(changes, t) }}
+	style := NewDateFieldStyle(th, f)
+	frame := func() {
+		var ops op.Ops
+		style.Layout(layout.Context{Ops: &ops, Constraints: layout.Exact(image.Point{X: 400, Y: 100})})
+	}
+	frame()
+	f.Editor.SetText("2024-13")
+	frame()
+	if f.Err() != nil || len(changes) != 0 {
+		t.Fatalf("validated while typing: %v, %v", f.Err(), changes)
+	}
+	f.validate()
+	if f.Err() == nil {
+		t.Error("2024-13: want an error once validated")
+	}
+	f.Editor.SetText(" 2024-03-01 ")
+	frame()
+	if f.Err() != nil {
+		t.Errorf("the error is kept after a change: %v", f.Err())
+	}
+	f.validate()
+	want := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.Local)
+	if !f.Date().Equal(want) || f.Editor.Text() != "2024-03-01" || len(changes) != 1 {
+		t.Errorf("Date %v, text %q, changes %v, want %v normalized", f.Date(), f.Editor.Text(), changes, want)
+	}
+	if selected := f.Calendar.Selected(); len(selected) != 1 || !selected[0].Equal(want) {
+		t.Errorf("Calendar selection %v, want %v", selected, want)
+	}
+}
@@ -0,0 +1,94 @@
+package giowidgets
+
+import (
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget/material"
+	"time"
+)
+
+// DateTimePicker picks a day in its Calendar and the time of that day in its TimePicker. It holds
+// the state and is drawn by a DateTimePickerStyle.
+type DateTimePicker struct {
+	// Calendar picks the day, its OnCalendarDateClick is set by the DateTimePicker.
+	Calendar Calendar
+	// TimePicker picks the time of the day, its OnTimeChange, Locale, Clock and Location are set by
+	// the DateTimePicker.
+	TimePicker TimePicker
+	// OnTimeChange is called with the combined time when the day or the time of the day changes.
+	OnTimeChange
+	initialized bool
+}
+
+// Time returns the picked day at the picked time of the day, today of the Calendar at midnight
+// when none was picked.
+func (p *DateTimePicker) Time() time.Time {
+	if p.TimePicker.time.IsZero() {
+		return p.Calendar.Today()
+	}
+	return p.TimePicker.Time()
+}
+
+// SetTime picks the day and the time of the day of t, the day is selected in the Calendar unless
+// it is disabled.
+func (p *DateTimePicker) SetTime(t time.Time) {
+	p.TimePicker.SetTime(t)
+	p.Calendar.SetTime(t)
+	p.Calendar.ClearSelection()
+	p.Calendar.Select(t)
+}
+
+// update ties the Calendar and the TimePicker together.
+func (p *DateTimePicker) update() {
+	p.TimePicker.Locale = p.Calendar.Locale
+	p.TimePicker.Clock, p.TimePicker.Location = p.Calendar.Clock, p.Calendar.Location
+	if !p.initialized {
+		if p.TimePicker.time.IsZero() {
+			p.SetTime(p.Calendar.Today())
+		}
+		p.Calendar.OnCalendarDateClick = func(day time.Time) {
+			p.TimePicker.setTime(withClock(day, p.TimePicker.Time()))
+		}
+		p.TimePicker.OnTimeChange = func(t time.Time) {
+			// the Calendar keeps the time of the day as well
+			p.Calendar.SetTime(t)
+			if p.OnTimeChange != nil {
+				p.OnTimeChange(t)
+			}
+		}
+		p.initialized = true
+	}
+}
+
+// DateTimePickerStyle draws a DateTimePicker, its Calendar above its TimePicker. It is built by
+// NewDateTimePickerStyle and can be adjusted before calling Layout.
+type DateTimePickerStyle struct {
+	DateTimePicker *DateTimePicker
+	Calendar       CalendarStyle
+	TimePicker     TimePickerStyle
+	// Spacing separates the Calendar and the TimePicker.
+	Spacing unit.Dp
+}
+
+// NewDateTimePickerStyle returns the style drawing picker with the colors of th.
+func NewDateTimePickerStyle(th *material.Theme, picker *DateTimePicker) DateTimePickerStyle {
+	return DateTimePickerStyle{
+		DateTimePicker: picker,
+		Calendar:       NewCalendarStyle(th, &picker.Calendar),
+		TimePicker:     NewTimePickerStyle(th, &picker.TimePicker),
+		Spacing:        unit.Dp(16),
+	}
+}
+
+func (s DateTimePickerStyle) Layout(gtx Gtx) Dim {
+	s.DateTimePicker.update()
+	flex := Flex{Axis: layout.Vertical}
+	return flex.Layout(gtx,
+		layout.Flexed(1, s.Calendar.Layout),
+		layout.Rigid(layout.Spacer{Height: s.Spacing}.Layout),
+		layout.Rigid(func(gtx Gtx) Dim {
+			gtx.Constraints.Min.X = gtx.Constraints.Max.X
+			return layout.Center.Layout(gtx, s.TimePicker.Layout)
+		}),
+	)
+}
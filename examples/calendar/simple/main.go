@@ -26,8 +26,7 @@ func main() {
 }
 func loop(w *app.Window) error {
 	th := material.NewTheme(gofont.Collection())
-	c := giowidgets.Calendar{Theme: th}
-	c.Inset = layout.UniformInset(unit.Dp(16))
+	c := giowidgets.Calendar{}
 	c.FirstDayOfWeek = time.Monday
 	var ops op.Ops
 
@@ -39,7 +38,9 @@ func loop(w *app.Window) error {
 				return e.Err
 			case system.FrameEvent:
 				gtx := layout.NewContext(&ops, e)
-				c.Layout(gtx)
+				style := giowidgets.NewCalendarStyle(th, &c)
+				style.Inset = layout.UniformInset(unit.Dp(16))
+				style.Layout(gtx)
 				e.Frame(gtx.Ops)
 			}
 		}
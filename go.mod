@@ -6,6 +6,7 @@ require (
 	gioui.org v0.0.0-20230107005120-f8221bb2ab3a
 	gioui.org/x v0.0.0-20221229102800-fabadb41b212
 	golang.org/x/exp/shiny v0.0.0-20230113213754-f9f960f08ad4
+	golang.org/x/text v0.6.0
 )
 
 require (
@@ -16,5 +17,4 @@ require (
 	golang.org/x/exp v0.0.0-20230113213754-f9f960f08ad4 // indirect
 	golang.org/x/image v0.3.0 // indirect
 	golang.org/x/sys v0.4.0 // indirect
-	golang.org/x/text v0.6.0 // indirect
 )
@@ -1,30 +1,165 @@
 package giowidgets
 
-import "time"
+import (
+	"math"
+	"time"
+)
 
 // Ref https://stackoverflow.com/questions/36830212/get-the-first-and-last-day-of-current-month-in-go-golang
-func beginningOfMonth(date time.Time) time.Time {
-	return date.AddDate(0, 0, -date.Day()+1)
+func beginningOfMonth(cs CalendarSystem, date time.Time) time.Time {
+	return date.AddDate(0, 0, -cs.FromTime(date).Day+1)
 }
-func endOfMonth(date time.Time) time.Time {
-	return date.AddDate(0, 1, -date.Day())
+func endOfMonth(cs CalendarSystem, date time.Time) time.Time {
+	d := cs.FromTime(date)
+	return date.AddDate(0, 0, cs.DaysInMonth(d.Year, d.Month)-d.Day)
 }
 
-func firstDayOfWeek(tm time.Time, weekStartDay time.Weekday) time.Time {
-	//tm = time.Date(tm.Year(), tm.Month(), 1, 0, 0, 0, 0, tm.Location())
-	tm = beginningOfMonth(tm)
-	for tm.Weekday() != weekStartDay {
-		tm = tm.AddDate(0, 0, -1)
+// withClock returns the day of date at the time of the day and in the location of clock.
+func withClock(date, clock time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), clock.Second(), clock.Nanosecond(), clock.Location())
+}
+
+// weekModel describes how the days are laid out in the seven columns of the Calendar,
+// every week starting on start. It is shared by the header row and the body rows.
+type weekModel struct {
+	start time.Weekday
+}
+
+// weekdays returns the days of the week in the order of the columns.
+func (w weekModel) weekdays() [7]time.Weekday {
+	var weekdays [7]time.Weekday
+	for i := range weekdays {
+		weekdays[i] = (w.start + time.Weekday(i)) % 7
+	}
+	return weekdays
+}
+
+// column returns the index of the column displaying the weekday d.
+func (w weekModel) column(d time.Weekday) int {
+	return (int(d) - int(w.start) + 7) % 7
+}
+
+// weekStart returns the first day of the week containing tm.
+func (w weekModel) weekStart(tm time.Time) time.Time {
+	return tm.AddDate(0, 0, -w.column(tm.Weekday()))
+}
+
+// monthGrid returns the first day displayed in the grid of the month of tm in cs and the number of
+// rows, between 4 and 6, needed to display every day of that month.
+func (w weekModel) monthGrid(cs CalendarSystem, tm time.Time) (first time.Time, rows int) {
+	first = beginningOfMonth(cs, tm)
+	offset := w.column(first.Weekday())
+	d := cs.FromTime(tm)
+	rows = (offset + cs.DaysInMonth(d.Year, d.Month) + 6) / 7
+	return first.AddDate(0, 0, -offset), rows
+}
+
+// weekNumber returns the number of the week starting on rowStart. ISO weeks are numbered after
+// the Thursday of the row, while US weeks start counting from the week containing January 1st.
+func (w weekModel) weekNumber(rowStart time.Time, numbering WeekNumbering) int {
+	if numbering == ISOWeekNumbering {
+		_, week := rowStart.AddDate(0, 0, w.column(time.Thursday)).ISOWeek()
+		return week
+	}
+	rowEnd := rowStart.AddDate(0, 0, 6)
+	if rowEnd.Year() != rowStart.Year() {
+		return 1
+	}
+	january1 := time.Date(rowEnd.Year(), time.January, 1, 0, 0, 0, 0, rowEnd.Location())
+	return (rowEnd.YearDay()-1+w.column(january1.Weekday()))/7 + 1
+}
+
+// addMonths adds months to date in cs, clamping the day to the last day of the resulting month
+// instead of overflowing into the next one.
+func addMonths(cs CalendarSystem, date time.Time, months int) time.Time {
+	d := cs.FromTime(date)
+	year, month := d.Year, d.Month+months
+	for month > cs.MonthsInYear(year) {
+		month -= cs.MonthsInYear(year)
+		year++
+	}
+	for month < 1 {
+		year--
+		month += cs.MonthsInYear(year)
+	}
+	return withClock(cs.ToTime(clampDate(cs, CalendarDate{Year: year, Month: month, Day: d.Day}), date.Location()), date)
+}
+
+// addYears adds years to date in cs, keeping its month and clamping its day.
+func addYears(cs CalendarSystem, date time.Time, years int) time.Time {
+	d := cs.FromTime(date)
+	d.Year += years
+	return withClock(cs.ToTime(clampDate(cs, d), date.Location()), date)
+}
+
+// clampDate keeps the month and the day of date within the year and the month of date.
+func clampDate(cs CalendarSystem, date CalendarDate) CalendarDate {
+	if months := cs.MonthsInYear(date.Year); date.Month > months {
+		date.Month = months
+	}
+	if days := cs.DaysInMonth(date.Year, date.Month); date.Day > days {
+		date.Day = days
+	}
+	return date
+}
+
+// monthsBetween returns the number of months from the month of a to the month of b in cs.
+func monthsBetween(cs CalendarSystem, a, b time.Time) int {
+	da, db := cs.FromTime(a), cs.FromTime(b)
+	months := db.Month - da.Month
+	for year := da.Year; year < db.Year; year++ {
+		months += cs.MonthsInYear(year)
+	}
+	for year := db.Year; year < da.Year; year++ {
+		months -= cs.MonthsInYear(year)
+	}
+	return months
+}
+
+// sameDay reports whether a and b fall on the same calendar day.
+func sameDay(a, b time.Time) bool {
+	return compareDays(a, b) == 0
+}
+
+// compareDays compares the calendar days of a and b, ignoring the time of day.
+// It returns -1 if a is before b, 1 if a is after b and 0 if they are equal.
+func compareDays(a, b time.Time) int {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	switch {
+	case ay != by:
+		return compareInts(ay, by)
+	case am != bm:
+		return compareInts(int(am), int(bm))
+	default:
+		return compareInts(ad, bd)
+	}
+}
+
+// clampInt returns n bounded by min and max.
+func clampInt(n, min, max int) int {
+	if n < min {
+		return min
+	}
+	if n > max {
+		return max
 	}
-	return tm
+	return n
 }
-func lastDayOfWeek(tm time.Time, weekStartDay time.Weekday) time.Time {
-	tm = endOfMonth(tm)
-	weekEndDay := (weekStartDay + 6) % 7
-	for tm.Weekday() != weekEndDay {
-		tm = tm.AddDate(0, 0, 1)
+
+// floorDiv returns the index of the interval of length size holding x.
+func floorDiv(x float32, size int) int {
+	return int(math.Floor(float64(x) / float64(size)))
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
 	}
-	return tm
+	return 0
 }
 
 // GetYearsRangeButtons returns slice of yearButton with year range between startYear and upto but not including lastYear
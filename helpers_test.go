@@ -0,0 +1,35 @@
+package giowidgets
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMonthGrid(t *testing.T) {
+	for start := time.Sunday; start <= time.Saturday; start++ {
+		w := weekModel{start: start}
+		weekdays := w.weekdays()
+		for year := 1900; year <= 2100; year++ {
+			for month := time.January; month <= time.December; month++ {
+				firstOfMonth := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+				lastOfMonth := firstOfMonth.AddDate(0, 1, -1)
+				first, rows := w.monthGrid(Gregorian, firstOfMonth.AddDate(0, 0, 14))
+				if first.Weekday() != start || first.After(firstOfMonth) {
+					t.Fatalf("start %v, %d-%02d: first cell %v, want a %v on or before the 1st",
+						start, year, month, first.Format("2006-01-02 Mon"), start)
+				}
+				gridEnd := first.AddDate(0, 0, rows*7-1)
+				if rows < 4 || rows > 6 || gridEnd.Before(lastOfMonth) {
+					t.Fatalf("start %v, %d-%02d: %d rows ending %v, want 4 to 6 rows covering %v",
+						start, year, month, rows, gridEnd.Format("2006-01-02"), lastOfMonth.Format("2006-01-02"))
+				}
+				for i, weekday := range weekdays {
+					if got := first.AddDate(0, 0, i).Weekday(); got != weekday {
+						t.Fatalf("start %v, %d-%02d: column %d is a %v, header says %v",
+							start, year, month, i, got, weekday)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,318 @@
+package giowidgets
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/widget/material"
+	"image"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Holiday is a named day, Date is its midnight.
+type Holiday struct {
+	Name string
+	Date time.Time
+}
+
+// HolidayProvider is asked by the Calendar for the holidays of the displayed days, from start up to
+// but not including end.
+type HolidayProvider interface {
+	Holidays(start, end time.Time) []Holiday
+}
+
+// HolidayList is a HolidayProvider holding its holidays in memory.
+type HolidayList []Holiday
+
+func (l HolidayList) Holidays(start, end time.Time) []Holiday {
+	var holidays []Holiday
+	for _, h := range l {
+		if compareDays(h.Date, start) >= 0 && h.Date.Before(end) {
+			holidays = append(holidays, h)
+		}
+	}
+	return holidays
+}
+
+// LoadHolidaysJSON reads a list of holidays like [{"date": "2024-12-25", "name": "Christmas Day"}],
+// the dates are read in loc.
+func LoadHolidaysJSON(r io.Reader, loc *time.Location) (HolidayList, error) {
+	var entries []struct {
+		Date string `json:"date"`
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("holidays: %w", err)
+	}
+	var holidays HolidayList
+	for _, entry := range entries {
+		date, err := time.ParseInLocation("2006-01-02", entry.Date, loc)
+		if err != nil {
+			return nil, fmt.Errorf("holidays: invalid date %q of %q", entry.Date, entry.Name)
+		}
+		holidays = append(holidays, Holiday{Name: entry.Name, Date: date})
+	}
+	return holidays, nil
+}
+
+// LoadHolidaysCSV reads the lines date,name like 2024-12-25,Christmas Day, after an optional
+// date,name header. The dates are read in loc.
+func LoadHolidaysCSV(r io.Reader, loc *time.Location) (HolidayList, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+	var holidays HolidayList
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return holidays, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("holidays: %w", err)
+		}
+		if line == 1 && strings.EqualFold(record[0], "date") {
+			continue
+		}
+		date, err := time.ParseInLocation("2006-01-02", record[0], loc)
+		if err != nil {
+			return nil, fmt.Errorf("holidays: line %d: invalid date %q", line, record[0])
+		}
+		holidays = append(holidays, Holiday{Name: record[1], Date: date})
+	}
+}
+
+// HolidayRule computes the day of a holiday in a year, ok is false when it does not occur in that year.
+type HolidayRule interface {
+	Date(year int, loc *time.Location) (date time.Time, ok bool)
+}
+
+// FixedDate is a holiday falling on the same day every year.
+type FixedDate struct {
+	Month time.Month
+	Day   int
+}
+
+func (r FixedDate) Date(year int, loc *time.Location) (time.Time, bool) {
+	date := time.Date(year, r.Month, r.Day, 0, 0, 0, 0, loc)
+	// February 29 only occurs in the leap years
+	return date, date.Day() == r.Day
+}
+
+// NthWeekday is a holiday falling on the Nth weekday of a month, counting from its end when N is negative.
+type NthWeekday struct {
+	Month   time.Month
+	Weekday time.Weekday
+	N       int
+}
+
+func (r NthWeekday) Date(year int, loc *time.Location) (time.Time, bool) {
+	var date time.Time
+	switch {
+	case r.N > 0:
+		first := time.Date(year, r.Month, 1, 0, 0, 0, 0, loc)
+		date = first.AddDate(0, 0, (int(r.Weekday)-int(first.Weekday())+7)%7+(r.N-1)*7)
+	case r.N < 0:
+		last := time.Date(year, r.Month+1, 0, 0, 0, 0, 0, loc)
+		date = last.AddDate(0, 0, -(int(last.Weekday())-int(r.Weekday)+7)%7+(r.N+1)*7)
+	}
+	// there is no fifth weekday in some months
+	return date, r.N != 0 && date.Month() == r.Month
+}
+
+// EasterOffset is a holiday falling Days after Easter Sunday, or before it when Days is negative.
+// Orthodox uses the Easter of the Julian calendar.
+type EasterOffset struct {
+	Days     int
+	Orthodox bool
+}
+
+func (r EasterOffset) Date(year int, loc *time.Location) (time.Time, bool) {
+	month, day := easter(year)
+	if r.Orthodox {
+		month, day = orthodoxEaster(year)
+	}
+	return time.Date(year, month, day+r.Days, 0, 0, 0, 0, loc), true
+}
+
+// easter returns the day of Easter Sunday of the Gregorian calendar, by the anonymous Gregorian computus.
+func easter(year int) (time.Month, int) {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	return time.Month((h + l - 7*m + 114) / 31), (h+l-7*m+114)%31 + 1
+}
+
+// orthodoxEaster returns the Gregorian day of Easter Sunday of the Julian calendar, by the Meeus
+// Julian computus.
+func orthodoxEaster(year int) (time.Month, int) {
+	a, b, c := year%4, year%7, year%19
+	d := (19*c + 15) % 30
+	e := (2*a + 4*b - d + 34) % 7
+	month, day := time.Month((d+e+114)/31), (d+e+114)%31+1
+	// adds the difference between the Julian and the Gregorian calendars
+	date := time.Date(year, month, day+year/100-year/400-2, 0, 0, 0, 0, time.UTC)
+	return date.Month(), date.Day()
+}
+
+// Observed moves a holiday falling on a weekend by the given number of days, like to the following
+// Monday or to the Friday before.
+type Observed struct {
+	Rule     HolidayRule
+	Saturday int
+	Sunday   int
+}
+
+// ObservedOnMonday moves the holiday of rule falling on a weekend to the following Monday.
+func ObservedOnMonday(rule HolidayRule) Observed {
+	return Observed{Rule: rule, Saturday: 2, Sunday: 1}
+}
+
+func (r Observed) Date(year int, loc *time.Location) (time.Time, bool) {
+	date, ok := r.Rule.Date(year, loc)
+	switch date.Weekday() {
+	case time.Saturday:
+		date = date.AddDate(0, 0, r.Saturday)
+	case time.Sunday:
+		date = date.AddDate(0, 0, r.Sunday)
+	}
+	return date, ok
+}
+
+// HolidayDefinition names the holiday computed by Rule, from the year Since or always when it is zero.
+type HolidayDefinition struct {
+	Name  string
+	Rule  HolidayRule
+	Since int
+}
+
+// HolidaySet is a HolidayProvider computing its holidays from rules.
+type HolidaySet []HolidayDefinition
+
+func (s HolidaySet) Holidays(start, end time.Time) []Holiday {
+	var holidays []Holiday
+	// the observed days can move a holiday into the previous or the next year
+	for year := start.Year() - 1; year <= end.Year()+1; year++ {
+		for _, definition := range s {
+			if year < definition.Since {
+				continue
+			}
+			date, ok := definition.Rule.Date(year, start.Location())
+			if ok && compareDays(date, start) >= 0 && date.Before(end) {
+				holidays = append(holidays, Holiday{Name: definition.Name, Date: date})
+			}
+		}
+	}
+	sort.SliceStable(holidays, func(i, j int) bool {
+		return holidays[i].Date.Before(holidays[j].Date)
+	})
+	return holidays
+}
+
+// BundledHolidays holds the public holidays observed nationwide in some countries, keyed by their
+// ISO 3166-1 alpha-2 code.
+var BundledHolidays = map[string]HolidaySet{
+	"US": {
+		{Name: "New Year's Day", Rule: Observed{Rule: FixedDate{time.January, 1}, Saturday: -1, Sunday: 1}},
+		{Name: "Martin Luther King Jr. Day", Rule: NthWeekday{time.January, time.Monday, 3}},
+		{Name: "Washington's Birthday", Rule: NthWeekday{time.February, time.Monday, 3}},
+		{Name: "Memorial Day", Rule: NthWeekday{time.May, time.Monday, -1}},
+		{Name: "Juneteenth", Rule: Observed{Rule: FixedDate{time.June, 19}, Saturday: -1, Sunday: 1}, Since: 2021},
+		{Name: "Independence Day", Rule: Observed{Rule: FixedDate{time.July, 4}, Saturday: -1, Sunday: 1}},
+		{Name: "Labor Day", Rule: NthWeekday{time.September, time.Monday, 1}},
+		{Name: "Columbus Day", Rule: NthWeekday{time.October, time.Monday, 2}},
+		{Name: "Veterans Day", Rule: Observed{Rule: FixedDate{time.November, 11}, Saturday: -1, Sunday: 1}},
+		{Name: "Thanksgiving Day", Rule: NthWeekday{time.November, time.Thursday, 4}},
+		{Name: "Christmas Day", Rule: Observed{Rule: FixedDate{time.December, 25}, Saturday: -1, Sunday: 1}},
+	},
+	// England and Wales
+	"GB": {
+		{Name: "New Year's Day", Rule: ObservedOnMonday(FixedDate{time.January, 1})},
+		{Name: "Good Friday", Rule: EasterOffset{Days: -2}},
+		{Name: "Easter Monday", Rule: EasterOffset{Days: 1}},
+		{Name: "Early May Bank Holiday", Rule: NthWeekday{time.May, time.Monday, 1}},
+		{Name: "Spring Bank Holiday", Rule: NthWeekday{time.May, time.Monday, -1}},
+		{Name: "Summer Bank Holiday", Rule: NthWeekday{time.August, time.Monday, -1}},
+		// Christmas Day and Boxing Day both move two days when one of them falls on a weekend
+		{Name: "Christmas Day", Rule: Observed{Rule: FixedDate{time.December, 25}, Saturday: 2, Sunday: 2}},
+		{Name: "Boxing Day", Rule: Observed{Rule: FixedDate{time.December, 26}, Saturday: 2, Sunday: 2}},
+	},
+	"DE": {
+		{Name: "New Year's Day", Rule: FixedDate{time.January, 1}},
+		{Name: "Good Friday", Rule: EasterOffset{Days: -2}},
+		{Name: "Easter Monday", Rule: EasterOffset{Days: 1}},
+		{Name: "Labour Day", Rule: FixedDate{time.May, 1}},
+		{Name: "Ascension Day", Rule: EasterOffset{Days: 39}},
+		{Name: "Whit Monday", Rule: EasterOffset{Days: 50}},
+		{Name: "German Unity Day", Rule: FixedDate{time.October, 3}},
+		{Name: "Christmas Day", Rule: FixedDate{time.December, 25}},
+		{Name: "Boxing Day", Rule: FixedDate{time.December, 26}},
+	},
+	"FR": {
+		{Name: "New Year's Day", Rule: FixedDate{time.January, 1}},
+		{Name: "Easter Monday", Rule: EasterOffset{Days: 1}},
+		{Name: "Labour Day", Rule: FixedDate{time.May, 1}},
+		{Name: "Victory in Europe Day", Rule: FixedDate{time.May, 8}},
+		{Name: "Ascension Day", Rule: EasterOffset{Days: 39}},
+		{Name: "Whit Monday", Rule: EasterOffset{Days: 50}},
+		{Name: "Bastille Day", Rule: FixedDate{time.July, 14}},
+		{Name: "Assumption Day", Rule: FixedDate{time.August, 15}},
+		{Name: "All Saints' Day", Rule: FixedDate{time.November, 1}},
+		{Name: "Armistice Day", Rule: FixedDate{time.November, 11}},
+		{Name: "Christmas Day", Rule: FixedDate{time.December, 25}},
+	},
+}
+
+// loadHolidays queries the HolidayProvider for the days of the grid of view and names the holidays of its cells.
+func (c *Calendar) loadHolidays(view *monthView) {
+	cells := view.cellItemsArr[:view.rows*7]
+	for _, cell := range cells {
+		cell.holiday = ""
+	}
+	if c.HolidayProvider == nil {
+		return
+	}
+	for _, h := range c.HolidayProvider.Holidays(cells[0].Time, cells[len(cells)-1].AddDate(0, 0, 1)) {
+		for _, cell := range cells {
+			if !sameDay(cell.Time, h.Date) {
+				continue
+			}
+			if cell.holiday != "" {
+				cell.holiday += ", "
+			}
+			cell.holiday += h.Name
+		}
+	}
+}
+
+// drawTooltip draws label below the cell of the given size, over the cells laid out after it.
+func (s *CalendarStyle) drawTooltip(gtx Gtx, label string, cellSize image.Point) {
+	macro := op.Record(gtx.Ops)
+	op.Offset(image.Point{Y: cellSize.Y}).Add(gtx.Ops)
+	gtx.Constraints.Min = image.Point{}
+	gtx.Constraints.Max.X = cellSize.X * 3
+	content := op.Record(gtx.Ops)
+	inset := Inset{Top: 4, Bottom: 4, Left: 8, Right: 8}
+	d := inset.Layout(gtx, func(gtx Gtx) Dim {
+		label := material.Label(s.Theme, s.EventTextSize, label)
+		label.Color = s.TooltipFg
+		return label.Layout(gtx)
+	})
+	call := content.Stop()
+	rect := image.Rectangle{Max: d.Size}
+	paint.FillShape(gtx.Ops, s.TooltipBg, clip.UniformRRect(rect, gtx.Dp(4)).Op(gtx.Ops))
+	call.Add(gtx.Ops)
+	op.Defer(gtx.Ops, macro.Stop())
+}
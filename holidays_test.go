@@ -0,0 +1,141 @@
+package giowidgets
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEaster(t *testing.T) {
+	tests := []struct {
+		year     int
+		orthodox bool
+		want     string
+	}{
+		{2024, false, "2024-03-31"},
+		{2025, false, "2025-04-20"},
+		{2026, false, "2026-04-05"},
+		{2000, false, "2000-04-23"},
+		// the earliest and the latest possible dates
+		{1818, false, "1818-03-22"},
+		{2038, false, "2038-04-25"},
+		{2024, true, "2024-05-05"},
+		{2025, true, "2025-04-20"},
+		{2026, true, "2026-04-12"},
+		{2021, true, "2021-05-02"},
+	}
+	for _, test := range tests {
+		date, ok := EasterOffset{Orthodox: test.orthodox}.Date(test.year, time.UTC)
+		if got := date.Format("2006-01-02"); !ok || got != test.want {
+			t.Errorf("Easter %d (orthodox %v) = %s, want %s", test.year, test.orthodox, got, test.want)
+		}
+	}
+}
+
+func TestHolidayRules(t *testing.T) {
+	tests := []struct {
+		name string
+		rule HolidayRule
+		year int
+		want string
+	}{
+		{"Thanksgiving", NthWeekday{time.November, time.Thursday, 4}, 2024, "2024-11-28"},
+		{"Memorial Day", NthWeekday{time.May, time.Monday, -1}, 2024, "2024-05-27"},
+		{"fifth Monday of February", NthWeekday{time.February, time.Monday, 5}, 2024, ""},
+		{"February 29", FixedDate{time.February, 29}, 2023, ""},
+		{"February 29", FixedDate{time.February, 29}, 2024, "2024-02-29"},
+		{"a Saturday on Monday", ObservedOnMonday(FixedDate{time.January, 1}), 2022, "2022-01-03"},
+		{"a Sunday on Monday", ObservedOnMonday(FixedDate{time.January, 1}), 2023, "2023-01-02"},
+		{"a weekday kept", ObservedOnMonday(FixedDate{time.January, 1}), 2024, "2024-01-01"},
+		{"a Saturday on Friday", Observed{Rule: FixedDate{time.December, 25}, Saturday: -1, Sunday: 1}, 2021, "2021-12-24"},
+	}
+	for _, test := range tests {
+		date, ok := test.rule.Date(test.year, time.UTC)
+		got := ""
+		if ok {
+			got = date.Format("2006-01-02")
+		}
+		if got != test.want {
+			t.Errorf("%s in %d = %q, want %q", test.name, test.year, got, test.want)
+		}
+	}
+}
+
+// holidayDates returns the holidays of the bundled set of country in the window, like "2021-12-27 Christmas Day".
+func holidayDates(country string, start, end time.Time) []string {
+	var dates []string
+	for _, h := range BundledHolidays[country].Holidays(start, end) {
+		dates = append(dates, h.Date.Format("2006-01-02 Mon ")+h.Name)
+	}
+	return dates
+}
+
+func TestBundledHolidays(t *testing.T) {
+	tests := []struct {
+		country    string
+		start, end time.Time
+		want       []string
+	}{
+		{
+			"GB", time.Date(2021, time.December, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, time.January, 5, 0, 0, 0, 0, time.UTC),
+			[]string{"2021-12-27 Mon Christmas Day", "2021-12-28 Tue Boxing Day", "2022-01-03 Mon New Year's Day"},
+		},
+		{
+			"GB", time.Date(2022, time.December, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC),
+			[]string{"2022-12-26 Mon Boxing Day", "2022-12-27 Tue Christmas Day"},
+		},
+		{
+			// New Year's Day 2022 is a Saturday, observed on the last day of 2021
+			"US", time.Date(2021, time.December, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, time.January, 5, 0, 0, 0, 0, time.UTC),
+			[]string{"2021-12-24 Fri Christmas Day", "2021-12-31 Fri New Year's Day"},
+		},
+		{
+			"US", time.Date(2020, time.June, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, time.July, 1, 0, 0, 0, 0, time.UTC),
+			[]string{
+				"2020-07-03 Fri Independence Day", "2020-09-07 Mon Labor Day", "2020-10-12 Mon Columbus Day",
+				"2020-11-11 Wed Veterans Day", "2020-11-26 Thu Thanksgiving Day", "2020-12-25 Fri Christmas Day",
+				"2021-01-01 Fri New Year's Day", "2021-01-18 Mon Martin Luther King Jr. Day",
+				"2021-02-15 Mon Washington's Birthday", "2021-05-31 Mon Memorial Day", "2021-06-18 Fri Juneteenth",
+			},
+		},
+		{
+			"DE", time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC),
+			[]string{
+				"2024-03-29 Fri Good Friday", "2024-04-01 Mon Easter Monday", "2024-05-01 Wed Labour Day",
+				"2024-05-09 Thu Ascension Day", "2024-05-20 Mon Whit Monday",
+			},
+		},
+	}
+	for _, test := range tests {
+		got := holidayDates(test.country, test.start, test.end)
+		if strings.Join(got, ", ") != strings.Join(test.want, ", ") {
+			t.Errorf("%s from %v:\n got %v\nwant %v", test.country, test.start.Format("2006-01-02"), got, test.want)
+		}
+	}
+}
+
+func TestLoadHolidays(t *testing.T) {
+	want := "2024-12-25 Christmas Day, 2024-12-26 Boxing Day"
+	format := func(list HolidayList) string {
+		var dates []string
+		for _, h := range list {
+			dates = append(dates, h.Date.Format("2006-01-02 ")+h.Name)
+		}
+		return strings.Join(dates, ", ")
+	}
+	list, err := LoadHolidaysJSON(strings.NewReader(`[{"date": "2024-12-25", "name": "Christmas Day"}, {"date": "2024-12-26", "name": "Boxing Day"}]`), time.UTC)
+	if err != nil || format(list) != want {
+		t.Errorf("LoadHolidaysJSON = %q, %v, want %q", format(list), err, want)
+	}
+	list, err = LoadHolidaysCSV(strings.NewReader("date,name\n2024-12-25,Christmas Day\n2024-12-26, Boxing Day\n"), time.UTC)
+	if err != nil || format(list) != want {
+		t.Errorf("LoadHolidaysCSV = %q, %v, want %q", format(list), err, want)
+	}
+	if _, err := LoadHolidaysCSV(strings.NewReader("25/12/2024,Christmas Day\n"), time.UTC); err == nil {
+		t.Error("LoadHolidaysCSV with an invalid date: want an error")
+	}
+	holidays := list.Holidays(time.Date(2024, time.December, 26, 0, 0, 0, 0, time.UTC), time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC))
+	if len(holidays) != 1 || holidays[0].Name != "Boxing Day" {
+		t.Errorf("Holidays from December 26 = %v, want Boxing Day", holidays)
+	}
+}
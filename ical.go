@@ -0,0 +1,677 @@
+package giowidgets
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseICS reads the VEVENT components of an iCalendar (RFC 5545) stream. Times qualified by a TZID
+// use the matching VTIMEZONE of the stream or, without one, the IANA time zone of that name.
+// Floating times and all-day dates are read in the local time zone. The recurring events keep
+// their RRULE, RDATE and EXDATE in their Recurrence, the instances modified by a VEVENT having a
+// RECURRENCE-ID are excluded from it. Only a malformed stream is an error, the events which can't be
+// read entirely are degraded or skipped as described by ParseICSWarnings.
+func ParseICS(r io.Reader) (EventList, error) {
+	events, _, err := ParseICSWarnings(r)
+	return events, err
+}
+
+// ParseICSWarnings is ParseICS also returning the problems of the events it degraded or skipped.
+// A time in an unknown time zone is read as a floating time, an event with an unsupported RRULE is
+// kept as a single event and an event without a valid DTSTART is skipped.
+func ParseICSWarnings(r io.Reader) (events EventList, warnings []error, err error) {
+	root, err := readICSComponents(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	d := &icsDecoder{zones: map[string]*icsTimeZone{}}
+	for _, calendar := range root.children {
+		for _, component := range calendar.children {
+			if component.name == "VTIMEZONE" {
+				zone, err := parseICSTimeZone(component)
+				if err != nil {
+					d.warn(err)
+					continue
+				}
+				d.zones[zone.id] = zone
+			}
+		}
+	}
+	overridden := map[string][]time.Time{}
+	for _, calendar := range root.children {
+		for _, component := range calendar.children {
+			if component.name != "VEVENT" {
+				continue
+			}
+			event, err := d.parseEvent(component)
+			if err != nil {
+				d.warn(err)
+				continue
+			}
+			if p, ok := component.property("RECURRENCE-ID"); ok {
+				if t, _, err := d.parseTime(p); err != nil {
+					d.warn(err)
+				} else {
+					overridden[event.ID] = append(overridden[event.ID], t)
+				}
+			}
+			events = append(events, event)
+		}
+	}
+	for _, event := range events {
+		if event.Recurrence != nil {
+			event.Recurrence.ExDates = append(event.Recurrence.ExDates, overridden[event.ID]...)
+		}
+	}
+	return events, d.warnings, nil
+}
+
+// icsDecoder reads the events of a stream with its time zones, collecting the problems of the
+// events it degrades.
+type icsDecoder struct {
+	zones    map[string]*icsTimeZone
+	warnings []error
+}
+
+func (d *icsDecoder) warn(err error) {
+	d.warnings = append(d.warnings, err)
+}
+
+// icsProperty is a content line, NAME;PARAM=value:value.
+type icsProperty struct {
+	name   string
+	params map[string]string
+	value  string
+	line   int
+}
+
+// icsComponent is a BEGIN:name ... END:name block.
+type icsComponent struct {
+	name       string
+	properties []icsProperty
+	children   []*icsComponent
+}
+
+// property returns the first property called name.
+func (c *icsComponent) property(name string) (icsProperty, bool) {
+	for _, p := range c.properties {
+		if p.name == name {
+			return p, true
+		}
+	}
+	return icsProperty{}, false
+}
+
+// readICSComponents unfolds the content lines of r and nests them into their components,
+// the returned root holds the top level components.
+func readICSComponents(r io.Reader) (*icsComponent, error) {
+	root := &icsComponent{}
+	stack := []*icsComponent{root}
+	var lines []string
+	var numbers []int
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if number == 1 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line == "" {
+			continue
+		}
+		lines = append(lines, line)
+		numbers = append(numbers, number)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	for i, line := range lines {
+		p, err := parseICSProperty(line, numbers[i])
+		if err != nil {
+			return nil, err
+		}
+		current := stack[len(stack)-1]
+		switch p.name {
+		case "BEGIN":
+			component := &icsComponent{name: strings.ToUpper(p.value)}
+			current.children = append(current.children, component)
+			stack = append(stack, component)
+		case "END":
+			if len(stack) == 1 || current.name != strings.ToUpper(p.value) {
+				return nil, fmt.Errorf("ics: line %d: unexpected END:%s", p.line, p.value)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			current.properties = append(current.properties, p)
+		}
+	}
+	if len(stack) > 1 {
+		return nil, fmt.Errorf("ics: missing END:%s", stack[len(stack)-1].name)
+	}
+	return root, nil
+}
+
+// parseICSProperty splits a content line into its name, parameters and value.
+func parseICSProperty(line string, number int) (icsProperty, error) {
+	p := icsProperty{params: map[string]string{}, line: number}
+	quoted := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		}
+		if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return p, fmt.Errorf("ics: line %d: missing ':' in %q", number, line)
+	}
+	p.value = line[colon+1:]
+	parts := splitICSQuoted(line[:colon], ';')
+	p.name = strings.ToUpper(parts[0])
+	for _, param := range parts[1:] {
+		name, value, _ := strings.Cut(param, "=")
+		p.params[strings.ToUpper(name)] = strings.Trim(value, `"`)
+	}
+	return p, nil
+}
+
+// splitICSQuoted splits s around sep, ignoring the separators within double quotes.
+func splitICSQuoted(s string, sep rune) []string {
+	var parts []string
+	quoted := false
+	start := 0
+	for i, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == sep && !quoted:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// unescapeICSText reverts the escaping of TEXT values.
+func unescapeICSText(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// parseEvent reads a VEVENT, the error is returned for an event which can't be read at all.
+func (d *icsDecoder) parseEvent(component *icsComponent) (Event, error) {
+	var event Event
+	if p, ok := component.property("UID"); ok {
+		event.ID = unescapeICSText(p.value)
+	}
+	if p, ok := component.property("SUMMARY"); ok {
+		event.Title = unescapeICSText(p.value)
+	}
+	start, ok := component.property("DTSTART")
+	if !ok {
+		return event, fmt.Errorf("ics: VEVENT %q has no DTSTART", event.ID)
+	}
+	var err error
+	event.Start, event.AllDay, err = d.parseTime(start)
+	if err != nil {
+		return event, err
+	}
+	event.End = event.Start
+	if event.AllDay {
+		event.End = event.Start.AddDate(0, 0, 1)
+	}
+	if end, ok := component.property("DTEND"); ok {
+		if t, _, err := d.parseTime(end); err != nil {
+			d.warn(err)
+		} else {
+			event.End = t
+		}
+	} else if duration, ok := component.property("DURATION"); ok {
+		if t, err := addICSDuration(event.Start, duration); err != nil {
+			d.warn(err)
+		} else {
+			event.End = t
+		}
+	}
+	if p, ok := component.property("RRULE"); ok {
+		recurrence, err := ParseRRule(p.value, event.Start.Location())
+		if err != nil {
+			d.warn(fmt.Errorf("ics: line %d: %w, VEVENT %q is read as a single event", p.line, err, event.ID))
+		} else {
+			event.Recurrence = &recurrence
+		}
+	}
+	for _, p := range component.properties {
+		if p.name != "RDATE" && p.name != "EXDATE" {
+			continue
+		}
+		if event.Recurrence == nil {
+			event.Recurrence = &Recurrence{Count: 1}
+		}
+		for _, value := range strings.Split(p.value, ",") {
+			// the start of a PERIOD value
+			value, _, _ = strings.Cut(value, "/")
+			date := p
+			date.value = value
+			t, _, err := d.parseTime(date)
+			if err != nil {
+				d.warn(err)
+				continue
+			}
+			if p.name == "RDATE" {
+				event.Recurrence.RDates = append(event.Recurrence.RDates, t)
+			} else {
+				event.Recurrence.ExDates = append(event.Recurrence.ExDates, t)
+			}
+		}
+	}
+	return event, nil
+}
+
+// parseTime reads a DATE or DATE-TIME property, allDay is set for a DATE. A time in an unknown time
+// zone is read as a floating time.
+func (d *icsDecoder) parseTime(p icsProperty) (t time.Time, allDay bool, err error) {
+	if p.params["VALUE"] == "DATE" || len(p.value) == len("20060102") {
+		t, err = time.ParseInLocation("20060102", p.value, time.Local)
+		if err != nil {
+			return t, true, fmt.Errorf("ics: line %d: invalid date %q", p.line, p.value)
+		}
+		return t, true, nil
+	}
+	if strings.HasSuffix(p.value, "Z") {
+		t, err = time.Parse("20060102T150405Z", p.value)
+	} else {
+		t, err = time.ParseInLocation("20060102T150405", p.value, time.UTC)
+	}
+	if err != nil {
+		return t, false, fmt.Errorf("ics: line %d: invalid date-time %q", p.line, p.value)
+	}
+	if strings.HasSuffix(p.value, "Z") {
+		return t, false, nil
+	}
+	tzid := p.params["TZID"]
+	loc := time.Local
+	if zone, ok := d.zones[tzid]; ok {
+		loc = zone.loc
+	} else if tzid != "" {
+		if loc, err = time.LoadLocation(strings.TrimPrefix(tzid, "/")); err != nil {
+			d.warn(fmt.Errorf("ics: line %d: unknown time zone %q, read as a floating time", p.line, tzid))
+			loc = time.Local
+		}
+	}
+	return wallClock(t, loc), false, nil
+}
+
+// wallClock returns the time in loc showing the same wall clock as t.
+func wallClock(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, loc)
+}
+
+// addICSDuration adds the DURATION value of p, like P1W, P2D or PT1H30M, to t. Weeks and days
+// are added on the wall clock, hours, minutes and seconds as elapsed time.
+func addICSDuration(t time.Time, p icsProperty) (time.Time, error) {
+	invalid := fmt.Errorf("ics: line %d: invalid duration %q", p.line, p.value)
+	value := p.value
+	sign := 1
+	switch {
+	case strings.HasPrefix(value, "-"):
+		sign = -1
+		value = value[1:]
+	case strings.HasPrefix(value, "+"):
+		value = value[1:]
+	}
+	if !strings.HasPrefix(value, "P") || len(value) < 3 {
+		return t, invalid
+	}
+	var days int
+	var elapsed time.Duration
+	inTime := false
+	number := ""
+	for _, r := range value[1:] {
+		if r >= '0' && r <= '9' {
+			number += string(r)
+			continue
+		}
+		if r == 'T' {
+			inTime = true
+			continue
+		}
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return t, invalid
+		}
+		number = ""
+		switch {
+		case r == 'W' && !inTime:
+			days += 7 * n
+		case r == 'D' && !inTime:
+			days += n
+		case r == 'H' && inTime:
+			elapsed += time.Duration(n) * time.Hour
+		case r == 'M' && inTime:
+			elapsed += time.Duration(n) * time.Minute
+		case r == 'S' && inTime:
+			elapsed += time.Duration(n) * time.Second
+		default:
+			return t, invalid
+		}
+	}
+	if number != "" {
+		return t, invalid
+	}
+	return t.AddDate(0, 0, sign*days).Add(time.Duration(sign) * elapsed), nil
+}
+
+// icsTimeZone is a VTIMEZONE, the offset from UTC of each of its observances starts at an onset
+// given on the wall clock of the previous observance.
+type icsTimeZone struct {
+	id          string
+	observances []icsObservance
+	loc         *time.Location
+}
+
+// icsObservance is a STANDARD or DAYLIGHT block of a VTIMEZONE.
+type icsObservance struct {
+	name         string
+	abbreviation string
+	start        time.Time
+	offsetFrom   int
+	offsetTo     int
+	// month and weekday, with its ordinal within the month, are read from a yearly RRULE
+	month    time.Month
+	weekday  time.Weekday
+	ordinal  int
+	until    time.Time
+	rdates   []time.Time
+	repeated bool
+}
+
+func parseICSTimeZone(component *icsComponent) (*icsTimeZone, error) {
+	zone := &icsTimeZone{}
+	if p, ok := component.property("TZID"); ok {
+		zone.id = p.value
+	}
+	for _, child := range component.children {
+		if child.name != "STANDARD" && child.name != "DAYLIGHT" {
+			continue
+		}
+		o := icsObservance{name: child.name}
+		if p, ok := child.property("TZNAME"); ok {
+			o.abbreviation = unescapeICSText(p.value)
+		}
+		start, ok := child.property("DTSTART")
+		if !ok {
+			return nil, fmt.Errorf("ics: %s of VTIMEZONE %q has no DTSTART", child.name, zone.id)
+		}
+		var err error
+		if o.start, err = time.ParseInLocation("20060102T150405", start.value, time.UTC); err != nil {
+			return nil, fmt.Errorf("ics: line %d: invalid date-time %q", start.line, start.value)
+		}
+		for _, name := range []string{"TZOFFSETFROM", "TZOFFSETTO"} {
+			p, ok := child.property(name)
+			if !ok {
+				return nil, fmt.Errorf("ics: %s of VTIMEZONE %q has no %s", child.name, zone.id, name)
+			}
+			offset, err := parseICSOffset(p)
+			if err != nil {
+				return nil, err
+			}
+			if name == "TZOFFSETFROM" {
+				o.offsetFrom = offset
+			} else {
+				o.offsetTo = offset
+			}
+		}
+		if p, ok := child.property("RRULE"); ok {
+			if err := o.parseYearlyRule(p); err != nil {
+				return nil, err
+			}
+		}
+		for _, p := range child.properties {
+			if p.name != "RDATE" {
+				continue
+			}
+			for _, value := range strings.Split(p.value, ",") {
+				rdate, err := time.ParseInLocation("20060102T150405", value, time.UTC)
+				if err != nil {
+					return nil, fmt.Errorf("ics: line %d: invalid date-time %q", p.line, value)
+				}
+				o.rdates = append(o.rdates, rdate)
+			}
+		}
+		zone.observances = append(zone.observances, o)
+	}
+	if len(zone.observances) == 0 {
+		return nil, fmt.Errorf("ics: VTIMEZONE %q has no STANDARD or DAYLIGHT", zone.id)
+	}
+	zone.loc = zone.location()
+	return zone, nil
+}
+
+// parseICSOffset reads a UTC offset like +0100 or -053000 as seconds.
+func parseICSOffset(p icsProperty) (int, error) {
+	value := p.value
+	if len(value) != 5 && len(value) != 7 || (value[0] != '+' && value[0] != '-') {
+		return 0, fmt.Errorf("ics: line %d: invalid offset %q", p.line, value)
+	}
+	seconds := 0
+	for i, unit := range []int{3600, 60, 1} {
+		if 1+2*i >= len(value) {
+			break
+		}
+		n, err := strconv.Atoi(value[1+2*i : 3+2*i])
+		if err != nil {
+			return 0, fmt.Errorf("ics: line %d: invalid offset %q", p.line, value)
+		}
+		seconds += n * unit
+	}
+	if value[0] == '-' {
+		seconds = -seconds
+	}
+	return seconds, nil
+}
+
+var icsWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// parseYearlyRule reads the FREQ=YEARLY;BYMONTH=m;BYDAY=nDD rules describing the transitions of time zones.
+func (o *icsObservance) parseYearlyRule(p icsProperty) error {
+	invalid := fmt.Errorf("ics: line %d: unsupported time zone rule %q", p.line, p.value)
+	o.repeated = true
+	o.month = o.start.Month()
+	o.weekday = o.start.Weekday()
+	o.ordinal = (o.start.Day()-1)/7 + 1
+	for _, part := range strings.Split(p.value, ";") {
+		name, value, _ := strings.Cut(part, "=")
+		switch name {
+		case "FREQ":
+			if value != "YEARLY" {
+				return invalid
+			}
+		case "BYMONTH":
+			month, err := strconv.Atoi(value)
+			if err != nil || month < 1 || month > 12 {
+				return invalid
+			}
+			o.month = time.Month(month)
+		case "BYDAY":
+			if len(value) < 2 {
+				return invalid
+			}
+			weekday, ok := icsWeekdays[value[len(value)-2:]]
+			if !ok {
+				return invalid
+			}
+			o.weekday = weekday
+			if ordinal := value[:len(value)-2]; ordinal != "" {
+				n, err := strconv.Atoi(ordinal)
+				if err != nil || n == 0 || n < -5 || n > 5 {
+					return invalid
+				}
+				o.ordinal = n
+			}
+		case "UNTIL":
+			until, err := time.Parse("20060102T150405Z", value)
+			if err != nil {
+				return invalid
+			}
+			o.until = until
+		}
+	}
+	return nil
+}
+
+// onsetIn returns the wall clock at which the observance starts in year, ok is false when it
+// does not start in that year.
+func (o icsObservance) onsetIn(year int) (onset time.Time, ok bool) {
+	if year < o.start.Year() {
+		return onset, false
+	}
+	if !o.repeated {
+		return o.start, year == o.start.Year()
+	}
+	onset = nthWeekday(year, o.month, o.weekday, o.ordinal)
+	onset = onset.Add(time.Duration(o.start.Hour())*time.Hour + time.Duration(o.start.Minute())*time.Minute + time.Duration(o.start.Second())*time.Second)
+	if onset.Before(o.start) || !o.until.IsZero() && onset.Add(-time.Duration(o.offsetFrom)*time.Second).After(o.until) {
+		return onset, false
+	}
+	return onset, true
+}
+
+// nthWeekday returns the nth weekday of month in year at midnight UTC, counting from the end of
+// the month when n is negative.
+func nthWeekday(year int, month time.Month, weekday time.Weekday, n int) time.Time {
+	if n > 0 {
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		day := first.AddDate(0, 0, (int(weekday)-int(first.Weekday())+7)%7+(n-1)*7)
+		if day.Month() != month {
+			day = day.AddDate(0, 0, -7)
+		}
+		return day
+	}
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+	day := last.AddDate(0, 0, -((int(last.Weekday())-int(weekday)+7)%7)+(n+1)*7)
+	if day.Month() != month {
+		day = day.AddDate(0, 0, 7)
+	}
+	return day
+}
+
+// location returns the zone as a time.Location holding the transitions of its observances up to
+// 2037, it falls back to the offset of the first observance when they can't be encoded.
+func (z *icsTimeZone) location() *time.Location {
+	type zoneType struct {
+		offset       int
+		dst          bool
+		abbreviation string
+	}
+	type transition struct {
+		at   int64
+		zone int
+	}
+	// the first type is used before the first transition
+	first := z.observances[0]
+	types := []zoneType{{offset: first.offsetFrom, abbreviation: icsAbbreviation("", first.offsetFrom)}}
+	var transitions []transition
+	for _, o := range z.observances {
+		t := zoneType{offset: o.offsetTo, dst: o.name == "DAYLIGHT", abbreviation: icsAbbreviation(o.abbreviation, o.offsetTo)}
+		zone := len(types)
+		for i := 1; i < len(types); i++ {
+			if types[i] == t {
+				zone = i
+			}
+		}
+		if zone == len(types) {
+			types = append(types, t)
+		}
+		onsets := append([]time.Time{}, o.rdates...)
+		if o.repeated {
+			for year := o.start.Year(); year <= 2037; year++ {
+				if onset, ok := o.onsetIn(year); ok {
+					onsets = append(onsets, onset)
+				}
+			}
+		} else {
+			onsets = append(onsets, o.start)
+		}
+		for _, onset := range onsets {
+			at := onset.Unix() - int64(o.offsetFrom)
+			if at >= math.MinInt32 && at <= math.MaxInt32 {
+				transitions = append(transitions, transition{at: at, zone: zone})
+			}
+		}
+	}
+	sort.Slice(transitions, func(i, j int) bool {
+		return transitions[i].at < transitions[j].at
+	})
+
+	// encodes the zone as version 1 TZif data, RFC 8536
+	var abbreviations []byte
+	data := append([]byte("TZif"), make([]byte, 16)...)
+	for _, n := range []int{0, 0, 0, len(transitions), len(types), 0} {
+		data = binary.BigEndian.AppendUint32(data, uint32(n))
+	}
+	for _, t := range transitions {
+		data = binary.BigEndian.AppendUint32(data, uint32(int32(t.at)))
+	}
+	for _, t := range transitions {
+		data = append(data, byte(t.zone))
+	}
+	for _, t := range types {
+		data = binary.BigEndian.AppendUint32(data, uint32(int32(t.offset)))
+		dst := byte(0)
+		if t.dst {
+			dst = 1
+		}
+		data = append(data, dst, byte(len(abbreviations)))
+		abbreviations = append(append(abbreviations, t.abbreviation...), 0)
+	}
+	binary.BigEndian.PutUint32(data[20+5*4:], uint32(len(abbreviations)))
+	data = append(data, abbreviations...)
+	loc, err := time.LoadLocationFromTZData(z.id, data)
+	if err != nil || len(types) > 255 {
+		return time.FixedZone(z.id, first.offsetTo)
+	}
+	return loc
+}
+
+// icsAbbreviation returns the TZNAME of an observance, or its offset like +0530 when it has none.
+func icsAbbreviation(name string, offset int) string {
+	if name != "" {
+		return name
+	}
+	sign := '+'
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+	return fmt.Sprintf("%c%02d%02d", sign, offset/3600, offset%3600/60)
+}
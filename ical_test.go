@@ -0,0 +1,237 @@
+package giowidgets
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// parseTestICS parses the VEVENT lines wrapped in a VCALENDAR, the lines are joined with CRLF.
+func parseTestICS(t *testing.T, lines ...string) EventList {
+	t.Helper()
+	lines = append(append([]string{"BEGIN:VCALENDAR", "VERSION:2.0"}, lines...), "END:VCALENDAR")
+	events, err := ParseICS(strings.NewReader(strings.Join(lines, "\r\n") + "\r\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return events
+}
+
+// outlookEastern is the VTIMEZONE written by Outlook for the eastern United States.
+var outlookEastern = []string{
+	"BEGIN:VTIMEZONE",
+	"TZID:Eastern Standard Time",
+	"BEGIN:STANDARD",
+	"DTSTART:16010101T020000",
+	"TZOFFSETFROM:-0400",
+	"TZOFFSETTO:-0500",
+	"RRULE:FREQ=YEARLY;BYDAY=1SU;BYMONTH=11",
+	"END:STANDARD",
+	"BEGIN:DAYLIGHT",
+	"DTSTART:16010101T020000",
+	"TZOFFSETFROM:-0500",
+	"TZOFFSETTO:-0400",
+	"RRULE:FREQ=YEARLY;BYDAY=2SU;BYMONTH=3",
+	"END:DAYLIGHT",
+	"END:VTIMEZONE",
+}
+
+func TestParseICSText(t *testing.T) {
+	events := parseTestICS(t,
+		"BEGIN:VEVENT",
+		"UID:text@example.com",
+		"DTSTART:20240105T090000Z",
+		"SUMMARY:Review\\, plan\\; and ship\\nthe release \\\\ v2 with a summary folded",
+		"  over two lines",
+		"END:VEVENT",
+	)
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want 1", len(events))
+	}
+	want := "Review, plan; and ship\nthe release \\ v2 with a summary folded over two lines"
+	if events[0].Title != want {
+		t.Errorf("Title = %q, want %q", events[0].Title, want)
+	}
+	if events[0].ID != "text@example.com" {
+		t.Errorf("ID = %q, want text@example.com", events[0].ID)
+	}
+}
+
+func TestParseICSDuration(t *testing.T) {
+	tests := []struct {
+		duration string
+		want     time.Time
+	}{
+		{"PT1H30M", time.Date(2024, time.January, 5, 10, 30, 0, 0, time.UTC)},
+		{"P1DT2H", time.Date(2024, time.January, 6, 11, 0, 0, 0, time.UTC)},
+		{"P1W", time.Date(2024, time.January, 12, 9, 0, 0, 0, time.UTC)},
+		{"PT45S", time.Date(2024, time.January, 5, 9, 0, 45, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		events := parseTestICS(t,
+			"BEGIN:VEVENT",
+			"DTSTART:20240105T090000Z",
+			"DURATION:"+test.duration,
+			"END:VEVENT",
+		)
+		if !events[0].End.Equal(test.want) {
+			t.Errorf("DURATION:%s: End = %v, want %v", test.duration, events[0].End, test.want)
+		}
+	}
+	events, warnings, err := ParseICSWarnings(strings.NewReader("BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART:20240105T090000Z\r\nDURATION:1H\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"))
+	if err != nil || len(events) != 1 || len(warnings) != 1 || !events[0].End.Equal(events[0].Start) {
+		t.Errorf("DURATION:1H = %v, %v, %v, want the event without a duration and a warning", events, warnings, err)
+	}
+}
+
+func TestParseICSDate(t *testing.T) {
+	events := parseTestICS(t,
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20240105",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20240108",
+		"DTEND;VALUE=DATE:20240111",
+		"END:VEVENT",
+	)
+	tests := []struct {
+		start, end time.Time
+	}{
+		{time.Date(2024, time.January, 5, 0, 0, 0, 0, time.Local), time.Date(2024, time.January, 6, 0, 0, 0, 0, time.Local)},
+		{time.Date(2024, time.January, 8, 0, 0, 0, 0, time.Local), time.Date(2024, time.January, 11, 0, 0, 0, 0, time.Local)},
+	}
+	for i, test := range tests {
+		e := events[i]
+		if !e.AllDay || !e.Start.Equal(test.start) || !e.End.Equal(test.end) {
+			t.Errorf("event %d: AllDay %v from %v to %v, want an all-day event from %v to %v", i, e.AllDay, e.Start, e.End, test.start, test.end)
+		}
+	}
+}
+
+func TestParseICSQuotedTZID(t *testing.T) {
+	events := parseTestICS(t,
+		"BEGIN:VEVENT",
+		`DTSTART;TZID="America/New_York";X-NOTE="a;b:c":20240105T090000`,
+		"END:VEVENT",
+	)
+	want := time.Date(2024, time.January, 5, 14, 0, 0, 0, time.UTC)
+	if !events[0].Start.Equal(want) {
+		t.Errorf("Start = %v, want %v", events[0].Start, want)
+	}
+	if name := events[0].Start.Location().String(); name != "America/New_York" {
+		t.Errorf("location %q, want America/New_York", name)
+	}
+}
+
+func TestParseICSOutlookTimeZone(t *testing.T) {
+	lines := append([]string{}, outlookEastern...)
+	lines = append(lines,
+		"BEGIN:VEVENT",
+		`DTSTART;TZID="Eastern Standard Time":20240309T090000`,
+		"DTEND;TZID=Eastern Standard Time:20240309T100000",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART;TZID=Eastern Standard Time:20240311T090000",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART;TZID=Eastern Standard Time:20241104T090000",
+		"END:VEVENT",
+	)
+	events := parseTestICS(t, lines...)
+	tests := []struct {
+		utc  time.Time
+		abbr string
+	}{
+		// the Saturday before the change to daylight saving time
+		{time.Date(2024, time.March, 9, 14, 0, 0, 0, time.UTC), "-0500"},
+		// the Monday after it
+		{time.Date(2024, time.March, 11, 13, 0, 0, 0, time.UTC), "-0400"},
+		// the Monday after the change back to standard time
+		{time.Date(2024, time.November, 4, 14, 0, 0, 0, time.UTC), "-0500"},
+	}
+	for i, test := range tests {
+		start := events[i].Start
+		if !start.Equal(test.utc) {
+			t.Errorf("event %d: Start = %v, want %v", i, start, test.utc)
+		}
+		if hour, _, _ := start.Clock(); hour != 9 {
+			t.Errorf("event %d: starts at %v on the wall clock, want 9:00", i, start)
+		}
+		if abbr, _ := start.Zone(); abbr != test.abbr {
+			t.Errorf("event %d: zone %q, want %q", i, abbr, test.abbr)
+		}
+	}
+	if end := events[0].End; !end.Equal(time.Date(2024, time.March, 9, 15, 0, 0, 0, time.UTC)) {
+		t.Errorf("End = %v, want 10:00 the same day", end)
+	}
+	// a weekly event keeps its wall clock across the change
+	week := events[0].Start.AddDate(0, 0, 7)
+	if hour, _, _ := week.Clock(); hour != 9 || !week.Equal(time.Date(2024, time.March, 16, 13, 0, 0, 0, time.UTC)) {
+		t.Errorf("a week later: %v, want 9:00 -0400", week)
+	}
+}
+
+func TestParseICSErrors(t *testing.T) {
+	for _, input := range []string{
+		"BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART:20240105T090000Z\r\n",
+		"BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART:20240105T090000Z\r\nEND:VCALENDAR\r\n",
+		"BEGIN:VCALENDAR\r\nDTSTART 20240105T090000Z\r\nEND:VCALENDAR\r\n",
+	} {
+		if _, err := ParseICS(strings.NewReader(input)); err == nil {
+			t.Errorf("ParseICS(%q): want an error", input)
+		}
+	}
+}
+
+// TestParseICSWarnings checks that the events which can't be read entirely are degraded or skipped
+// without dropping the others.
+func TestParseICSWarnings(t *testing.T) {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:no-start",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:bad-start",
+		"DTSTART:2024-01-05",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:unknown-zone",
+		"DTSTART;TZID=Nowhere/Unknown:20240105T090000",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:hourly",
+		"DTSTART:20240105T090000Z",
+		"RRULE:FREQ=HOURLY;COUNT=3",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:lowercase",
+		"DTSTART:20240105T090000Z",
+		"RRULE:freq=weekly;count=2;byday=fr;X-NAME=value",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}
+	events, warnings, err := ParseICSWarnings(strings.NewReader(strings.Join(lines, "\r\n") + "\r\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, e := range events {
+		ids = append(ids, e.ID)
+	}
+	if got := strings.Join(ids, " "); got != "unknown-zone hourly lowercase" {
+		t.Fatalf("events %s, want unknown-zone hourly lowercase", got)
+	}
+	if len(warnings) != 4 {
+		t.Errorf("warnings %v, want 4", warnings)
+	}
+	if start := events[0].Start; start.Location() != time.Local || !start.Equal(time.Date(2024, time.January, 5, 9, 0, 0, 0, time.Local)) {
+		t.Errorf("unknown time zone: Start = %v, want a floating 9:00", start)
+	}
+	if events[1].Recurrence != nil {
+		t.Errorf("unsupported RRULE: Recurrence = %+v, want a single event", events[1].Recurrence)
+	}
+	if r := events[2].Recurrence; r == nil || r.Frequency != FrequencyWeekly || r.Count != 2 || len(r.ByDay) != 1 || r.ByDay[0].Weekday != time.Friday {
+		t.Errorf("lowercase RRULE: Recurrence = %+v, want weekly on Friday twice", r)
+	}
+}
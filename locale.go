@@ -0,0 +1,481 @@
+package giowidgets
+
+import (
+	"fmt"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"strings"
+	"time"
+)
+
+// Locale holds the names and conventions used by the Calendar to display dates.
+// Weekday names are indexed by time.Weekday and month names by time.Month - 1.
+type Locale struct {
+	Tag                language.Tag
+	MonthNames         [12]string
+	ShortMonthNames    [12]string
+	WeekdayNames       [7]string
+	ShortWeekdayNames  [7]string
+	NarrowWeekdayNames [7]string
+	FirstDayOfWeek     time.Weekday
+	Weekend            []time.Weekday
+	// DateLayout is the numeric layout, as understood by time.Format, used to write a date.
+	DateLayout string
+	// TimeLayout is the layout, as understood by time.Format, used to write a time of the day.
+	TimeLayout string
+	// WeekLabel is the heading of the week numbers column.
+	WeekLabel string
+	// MoreEvents labels the chip of the n events not fitting in a day cell, like "+3 more".
+	MoreEvents func(n int) string
+	// DateField holds the hint letters and the error messages of a DateField.
+	DateField DateFieldTexts
+}
+
+// DateFieldTexts are the texts of a DateField in the language of a Locale.
+type DateFieldTexts struct {
+	// Year, Month and Day replace 2006, 01 and 02 of the Format in the hint, like YYYY-MM-DD.
+	Year, Month, Day string
+	// Invalid, Before and After are the formats of the errors of an unreadable date, given an
+	// example, and of a date before MinDate or after MaxDate, given that bound.
+	Invalid, Before, After string
+	// Unavailable is the error of a date rejected by IsDateDisabled.
+	Unavailable string
+}
+
+var englishDateField = DateFieldTexts{
+	Year: "YYYY", Month: "MM", Day: "DD",
+	Invalid:     "enter a date like %s",
+	Before:      "the date must not be before %s",
+	After:       "the date must not be after %s",
+	Unavailable: "the date is not available",
+}
+
+// moreEventsFormat returns a MoreEvents writing n with format.
+func moreEventsFormat(format string) func(n int) string {
+	return func(n int) string {
+		return fmt.Sprintf(format, n)
+	}
+}
+
+var englishMonthNames = [12]string{
+	"January", "February", "March", "April", "May", "June",
+	"July", "August", "September", "October", "November", "December",
+}
+var englishShortMonthNames = [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"}
+var englishWeekdayNames = [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+var englishShortWeekdayNames = [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+var englishNarrowWeekdayNames = [7]string{"S", "M", "T", "W", "T", "F", "S"}
+
+var portugueseMonthNames = [12]string{
+	"janeiro", "fevereiro", "março", "abril", "maio", "junho",
+	"julho", "agosto", "setembro", "outubro", "novembro", "dezembro",
+}
+var portugueseShortMonthNames = [12]string{"jan", "fev", "mar", "abr", "mai", "jun", "jul", "ago", "set", "out", "nov", "dez"}
+var portugueseWeekdayNames = [7]string{
+	"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado",
+}
+var portugueseShortWeekdayNames = [7]string{"dom", "seg", "ter", "qua", "qui", "sex", "sáb"}
+var portugueseNarrowWeekdayNames = [7]string{"D", "S", "T", "Q", "Q", "S", "S"}
+
+var portugueseDateField = DateFieldTexts{
+	Year: "AAAA", Month: "MM", Day: "DD",
+	Invalid:     "digite uma data como %s",
+	Before:      "a data não pode ser anterior a %s",
+	After:       "a data não pode ser posterior a %s",
+	Unavailable: "a data não está disponível",
+}
+
+var saturdaySunday = []time.Weekday{time.Saturday, time.Sunday}
+
+// bundledLocales are the locales the Calendar knows about, the first one is the fallback.
+var bundledLocales = []Locale{
+	{
+		Tag:                language.AmericanEnglish,
+		MonthNames:         englishMonthNames,
+		ShortMonthNames:    englishShortMonthNames,
+		WeekdayNames:       englishWeekdayNames,
+		ShortWeekdayNames:  englishShortWeekdayNames,
+		NarrowWeekdayNames: englishNarrowWeekdayNames,
+		FirstDayOfWeek:     time.Sunday,
+		Weekend:            saturdaySunday,
+		DateLayout:         "01/02/2006",
+		TimeLayout:         "3:04 PM",
+		WeekLabel:          "Wk",
+		MoreEvents:         moreEventsFormat("+%d more"),
+		DateField:          englishDateField,
+	},
+	{
+		Tag:                language.BritishEnglish,
+		MonthNames:         englishMonthNames,
+		ShortMonthNames:    englishShortMonthNames,
+		WeekdayNames:       englishWeekdayNames,
+		ShortWeekdayNames:  englishShortWeekdayNames,
+		NarrowWeekdayNames: englishNarrowWeekdayNames,
+		FirstDayOfWeek:     time.Monday,
+		Weekend:            saturdaySunday,
+		DateLayout:         "02/01/2006",
+		TimeLayout:         "15:04",
+		WeekLabel:          "Wk",
+		MoreEvents:         moreEventsFormat("+%d more"),
+		DateField:          englishDateField,
+	},
+	{
+		Tag: language.German,
+		MonthNames: [12]string{
+			"Januar", "Februar", "März", "April", "Mai", "Juni",
+			"Juli", "August", "September", "Oktober", "November", "Dezember",
+		},
+		ShortMonthNames:    [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+		WeekdayNames:       [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		ShortWeekdayNames:  [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		NarrowWeekdayNames: [7]string{"S", "M", "D", "M", "D", "F", "S"},
+		FirstDayOfWeek:     time.Monday,
+		Weekend:            saturdaySunday,
+		DateLayout:         "02.01.2006",
+		TimeLayout:         "15:04",
+		WeekLabel:          "KW",
+		MoreEvents:         moreEventsFormat("+%d weitere"),
+		DateField: DateFieldTexts{
+			Year: "JJJJ", Month: "MM", Day: "TT",
+			Invalid:     "geben Sie ein Datum wie %s ein",
+			Before:      "das Datum darf nicht vor dem %s liegen",
+			After:       "das Datum darf nicht nach dem %s liegen",
+			Unavailable: "das Datum ist nicht verfügbar",
+		},
+	},
+	{
+		Tag: language.French,
+		MonthNames: [12]string{
+			"janvier", "février", "mars", "avril", "mai", "juin",
+			"juillet", "août", "septembre", "octobre", "novembre", "décembre",
+		},
+		ShortMonthNames: [12]string{
+			"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc.",
+		},
+		WeekdayNames:       [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		ShortWeekdayNames:  [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		NarrowWeekdayNames: [7]string{"D", "L", "M", "M", "J", "V", "S"},
+		FirstDayOfWeek:     time.Monday,
+		Weekend:            saturdaySunday,
+		DateLayout:         "02/01/2006",
+		TimeLayout:         "15:04",
+		WeekLabel:          "Sem.",
+		MoreEvents:         moreEventsFormat("+%d de plus"),
+		DateField: DateFieldTexts{
+			Year: "AAAA", Month: "MM", Day: "JJ",
+			Invalid:     "saisissez une date comme %s",
+			Before:      "la date ne doit pas être antérieure au %s",
+			After:       "la date ne doit pas être postérieure au %s",
+			Unavailable: "la date n'est pas disponible",
+		},
+	},
+	{
+		Tag: language.Spanish,
+		MonthNames: [12]string{
+			"enero", "febrero", "marzo", "abril", "mayo", "junio",
+			"julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre",
+		},
+		ShortMonthNames:    [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+		WeekdayNames:       [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		ShortWeekdayNames:  [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		NarrowWeekdayNames: [7]string{"D", "L", "M", "X", "J", "V", "S"},
+		FirstDayOfWeek:     time.Monday,
+		Weekend:            saturdaySunday,
+		DateLayout:         "02/01/2006",
+		TimeLayout:         "15:04",
+		WeekLabel:          "Sem.",
+		MoreEvents:         moreEventsFormat("+%d más"),
+		DateField: DateFieldTexts{
+			Year: "AAAA", Month: "MM", Day: "DD",
+			Invalid:     "introduzca una fecha como %s",
+			Before:      "la fecha no puede ser anterior al %s",
+			After:       "la fecha no puede ser posterior al %s",
+			Unavailable: "la fecha no está disponible",
+		},
+	},
+	{
+		Tag: language.Italian,
+		MonthNames: [12]string{
+			"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno",
+			"luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre",
+		},
+		ShortMonthNames:    [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+		WeekdayNames:       [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+		ShortWeekdayNames:  [7]string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+		NarrowWeekdayNames: [7]string{"D", "L", "M", "M", "G", "V", "S"},
+		FirstDayOfWeek:     time.Monday,
+		Weekend:            saturdaySunday,
+		DateLayout:         "02/01/2006",
+		TimeLayout:         "15:04",
+		WeekLabel:          "Sett.",
+		MoreEvents:         moreEventsFormat("+%d altri"),
+		DateField: DateFieldTexts{
+			Year: "AAAA", Month: "MM", Day: "GG",
+			Invalid:     "inserisci una data come %s",
+			Before:      "la data non può essere precedente al %s",
+			After:       "la data non può essere successiva al %s",
+			Unavailable: "la data non è disponibile",
+		},
+	},
+	{
+		Tag:                language.BrazilianPortuguese,
+		MonthNames:         portugueseMonthNames,
+		ShortMonthNames:    portugueseShortMonthNames,
+		WeekdayNames:       portugueseWeekdayNames,
+		ShortWeekdayNames:  portugueseShortWeekdayNames,
+		NarrowWeekdayNames: portugueseNarrowWeekdayNames,
+		FirstDayOfWeek:     time.Sunday,
+		Weekend:            saturdaySunday,
+		DateLayout:         "02/01/2006",
+		TimeLayout:         "15:04",
+		WeekLabel:          "Sem.",
+		MoreEvents:         moreEventsFormat("+%d mais"),
+		DateField:          portugueseDateField,
+	},
+	{
+		Tag:                language.EuropeanPortuguese,
+		MonthNames:         portugueseMonthNames,
+		ShortMonthNames:    portugueseShortMonthNames,
+		WeekdayNames:       portugueseWeekdayNames,
+		ShortWeekdayNames:  portugueseShortWeekdayNames,
+		NarrowWeekdayNames: portugueseNarrowWeekdayNames,
+		FirstDayOfWeek:     time.Monday,
+		Weekend:            saturdaySunday,
+		DateLayout:         "02/01/2006",
+		TimeLayout:         "15:04",
+		WeekLabel:          "Sem.",
+		MoreEvents:         moreEventsFormat("+%d mais"),
+		DateField:          portugueseDateField,
+	},
+	{
+		Tag: language.Dutch,
+		MonthNames: [12]string{
+			"januari", "februari", "maart", "april", "mei", "juni",
+			"juli", "augustus", "september", "oktober", "november", "december",
+		},
+		ShortMonthNames:    [12]string{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+		WeekdayNames:       [7]string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+		ShortWeekdayNames:  [7]string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+		NarrowWeekdayNames: [7]string{"Z", "M", "D", "W", "D", "V", "Z"},
+		FirstDayOfWeek:     time.Monday,
+		Weekend:            saturdaySunday,
+		DateLayout:         "02-01-2006",
+		TimeLayout:         "15:04",
+		WeekLabel:          "Wk",
+		MoreEvents:         moreEventsFormat("+%d meer"),
+		DateField: DateFieldTexts{
+			Year: "JJJJ", Month: "MM", Day: "DD",
+			Invalid:     "voer een datum in zoals %s",
+			Before:      "de datum mag niet vóór %s liggen",
+			After:       "de datum mag niet na %s liggen",
+			Unavailable: "de datum is niet beschikbaar",
+		},
+	},
+	{
+		Tag: language.Russian,
+		MonthNames: [12]string{
+			"январь", "февраль", "март", "апрель", "май", "июнь",
+			"июль", "август", "сентябрь", "октябрь", "ноябрь", "декабрь",
+		},
+		ShortMonthNames: [12]string{
+			"янв.", "февр.", "март", "апр.", "май", "июнь", "июль", "авг.", "сент.", "окт.", "нояб.", "дек.",
+		},
+		WeekdayNames:       [7]string{"воскресенье", "понедельник", "вторник", "среда", "четверг", "пятница", "суббота"},
+		ShortWeekdayNames:  [7]string{"вс", "пн", "вт", "ср", "чт", "пт", "сб"},
+		NarrowWeekdayNames: [7]string{"В", "П", "В", "С", "Ч", "П", "С"},
+		FirstDayOfWeek:     time.Monday,
+		Weekend:            saturdaySunday,
+		DateLayout:         "02.01.2006",
+		TimeLayout:         "15:04",
+		WeekLabel:          "Нед.",
+		MoreEvents:         moreEventsFormat("ещё %d"),
+		DateField: DateFieldTexts{
+			Year: "ГГГГ", Month: "ММ", Day: "ДД",
+			Invalid:     "введите дату в формате %s",
+			Before:      "дата не может быть раньше %s",
+			After:       "дата не может быть позже %s",
+			Unavailable: "дата недоступна",
+		},
+	},
+	{
+		Tag:                language.Japanese,
+		MonthNames:         [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		ShortMonthNames:    [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		WeekdayNames:       [7]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+		ShortWeekdayNames:  [7]string{"日", "月", "火", "水", "木", "金", "土"},
+		NarrowWeekdayNames: [7]string{"日", "月", "火", "水", "木", "金", "土"},
+		FirstDayOfWeek:     time.Sunday,
+		Weekend:            saturdaySunday,
+		DateLayout:         "2006/01/02",
+		TimeLayout:         "15:04",
+		WeekLabel:          "週",
+		MoreEvents:         moreEventsFormat("他%d件"),
+		DateField: DateFieldTexts{
+			Year: "YYYY", Month: "MM", Day: "DD",
+			Invalid:     "%s のように日付を入力してください",
+			Before:      "%s より前の日付は指定できません",
+			After:       "%s より後の日付は指定できません",
+			Unavailable: "この日付は選択できません",
+		},
+	},
+	{
+		Tag: language.Chinese,
+		MonthNames: [12]string{
+			"一月", "二月", "三月", "四月", "五月", "六月", "七月", "八月", "九月", "十月", "十一月", "十二月",
+		},
+		ShortMonthNames:    [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		WeekdayNames:       [7]string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+		ShortWeekdayNames:  [7]string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"},
+		NarrowWeekdayNames: [7]string{"日", "一", "二", "三", "四", "五", "六"},
+		FirstDayOfWeek:     time.Monday,
+		Weekend:            saturdaySunday,
+		DateLayout:         "2006/01/02",
+		TimeLayout:         "15:04",
+		WeekLabel:          "周",
+		MoreEvents:         moreEventsFormat("还有%d项"),
+		DateField: DateFieldTexts{
+			Year: "YYYY", Month: "MM", Day: "DD",
+			Invalid:     "请输入日期，例如 %s",
+			Before:      "日期不能早于 %s",
+			After:       "日期不能晚于 %s",
+			Unavailable: "该日期不可用",
+		},
+	},
+	{
+		Tag: language.Persian,
+		MonthNames: [12]string{
+			"ژانویه", "فوریه", "مارس", "آوریل", "مه", "ژوئن", "ژوئیه", "اوت", "سپتامبر", "اکتبر", "نوامبر", "دسامبر",
+		},
+		ShortMonthNames: [12]string{
+			"ژانویه", "فوریه", "مارس", "آوریل", "مه", "ژوئن", "ژوئیه", "اوت", "سپتامبر", "اکتبر", "نوامبر", "دسامبر",
+		},
+		WeekdayNames:       [7]string{"یکشنبه", "دوشنبه", "سه‌شنبه", "چهارشنبه", "پنجشنبه", "جمعه", "شنبه"},
+		ShortWeekdayNames:  [7]string{"یکشنبه", "دوشنبه", "سه‌شنبه", "چهارشنبه", "پنجشنبه", "جمعه", "شنبه"},
+		NarrowWeekdayNames: [7]string{"ی", "د", "س", "چ", "پ", "ج", "ش"},
+		FirstDayOfWeek:     time.Saturday,
+		Weekend:            []time.Weekday{time.Friday},
+		DateLayout:         "2006/01/02",
+		TimeLayout:         "15:04",
+		WeekLabel:          "هفته",
+		MoreEvents:         moreEventsFormat("+%d مورد دیگر"),
+		DateField: DateFieldTexts{
+			Year: "YYYY", Month: "MM", Day: "DD",
+			Invalid:     "تاریخی مانند %s وارد کنید",
+			Before:      "تاریخ نباید قبل از %s باشد",
+			After:       "تاریخ نباید بعد از %s باشد",
+			Unavailable: "این تاریخ در دسترس نیست",
+		},
+	},
+	{
+		Tag: language.Arabic,
+		MonthNames: [12]string{
+			"يناير", "فبراير", "مارس", "أبريل", "مايو", "يونيو", "يوليو", "أغسطس", "سبتمبر", "أكتوبر", "نوفمبر", "ديسمبر",
+		},
+		ShortMonthNames: [12]string{
+			"يناير", "فبراير", "مارس", "أبريل", "مايو", "يونيو", "يوليو", "أغسطس", "سبتمبر", "أكتوبر", "نوفمبر", "ديسمبر",
+		},
+		WeekdayNames:       [7]string{"الأحد", "الاثنين", "الثلاثاء", "الأربعاء", "الخميس", "الجمعة", "السبت"},
+		ShortWeekdayNames:  [7]string{"الأحد", "الاثنين", "الثلاثاء", "الأربعاء", "الخميس", "الجمعة", "السبت"},
+		NarrowWeekdayNames: [7]string{"ح", "ن", "ث", "ر", "خ", "ج", "س"},
+		FirstDayOfWeek:     time.Sunday,
+		Weekend:            []time.Weekday{time.Friday, time.Saturday},
+		DateLayout:         "02/01/2006",
+		TimeLayout:         "15:04",
+		WeekLabel:          "أسبوع",
+		MoreEvents:         moreEventsFormat("+%d أخرى"),
+		DateField: DateFieldTexts{
+			Year: "YYYY", Month: "MM", Day: "DD",
+			Invalid:     "أدخل تاريخًا مثل %s",
+			Before:      "يجب ألا يكون التاريخ قبل %s",
+			After:       "يجب ألا يكون التاريخ بعد %s",
+			Unavailable: "هذا التاريخ غير متاح",
+		},
+	},
+}
+
+var localeMatcher = func() language.Matcher {
+	tags := make([]language.Tag, len(bundledLocales))
+	for i, l := range bundledLocales {
+		tags[i] = l.Tag
+	}
+	return language.NewMatcher(tags)
+}()
+
+var firstDayKeywords = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// LocaleFor returns the bundled Locale closest to tag, falling back to American English.
+// The first day of the week can be overridden with the "fw" Unicode extension, for
+// example "de-u-fw-sun".
+func LocaleFor(tag language.Tag) Locale {
+	_, index, _ := localeMatcher.Match(tag)
+	l := bundledLocales[index]
+	if day, ok := firstDayKeywords[tag.TypeForKey("fw")]; ok {
+		l.FirstDayOfWeek = day
+	}
+	return l
+}
+
+// monthIndex returns the index of the name of month m in a [12]string, wrapping the months out
+// of 1 to 12 around.
+func monthIndex(m int) int {
+	return ((m-1)%12 + 12) % 12
+}
+
+// MonthName returns the full name of month m.
+func (l Locale) MonthName(m time.Month) string {
+	return l.MonthNames[monthIndex(int(m))]
+}
+
+// ShortMonthName returns the abbreviated name of month m.
+func (l Locale) ShortMonthName(m time.Month) string {
+	return l.ShortMonthNames[monthIndex(int(m))]
+}
+
+// MoreEventsLabel returns the label of the chip of n hidden events, in English when MoreEvents is nil.
+func (l Locale) MoreEventsLabel(n int) string {
+	if l.MoreEvents == nil {
+		return fmt.Sprintf("+%d more", n)
+	}
+	return l.MoreEvents(n)
+}
+
+// dateFieldTexts returns the DateField texts, in English when they are missing.
+func (l Locale) dateFieldTexts() DateFieldTexts {
+	if l.DateField.Invalid == "" {
+		return englishDateField
+	}
+	return l.DateField
+}
+
+// hint returns layout with its year, month and day written with the letters of t, like YYYY-MM-DD.
+func (t DateFieldTexts) hint(layout string) string {
+	return strings.NewReplacer("2006", t.Year, "01", t.Month, "02", t.Day).Replace(layout)
+}
+
+// IsWeekend reports whether d is a weekend day in this locale.
+func (l Locale) IsWeekend(d time.Weekday) bool {
+	for _, weekendDay := range l.Weekend {
+		if weekendDay == d {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatDate writes the date of t using DateLayout.
+func (l Locale) FormatDate(t time.Time) string {
+	return t.Format(l.DateLayout)
+}
+
+// FormatTime writes the time of the day of t using TimeLayout.
+func (l Locale) FormatTime(t time.Time) string {
+	return t.Format(l.TimeLayout)
+}
+
+// Upper returns s in upper case following the casing rules of the locale.
+func (l Locale) Upper(s string) string {
+	return cases.Upper(l.Tag).String(s)
+}
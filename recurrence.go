@@ -0,0 +1,450 @@
+package giowidgets
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the interval at which a Recurrence repeats.
+type Frequency int
+
+const (
+	FrequencyDaily Frequency = iota
+	FrequencyWeekly
+	FrequencyMonthly
+	FrequencyYearly
+)
+
+// WeekdayRule selects a weekday, every one of the period when N is zero, otherwise the Nth of the
+// month, or of the year for a yearly Recurrence without months, counting from its end when N is negative.
+type WeekdayRule struct {
+	Weekday time.Weekday
+	N       int
+}
+
+// Recurrence repeats an Event following the RRULE, RDATE and EXDATE properties of RFC 5545.
+// The occurrences are computed on the wall clock of the location of the Start of the event, so
+// they keep their time of day across the daylight saving changes.
+type Recurrence struct {
+	Frequency Frequency
+	// Interval is the number of periods between the repetitions, 1 when zero.
+	Interval int
+	// Count limits the number of occurrences generated by the rule, Until the start of the last one.
+	// Both are unlimited when zero.
+	Count int
+	Until time.Time
+	// ByMonth, ByMonthDay and ByDay select the days of each period, ByMonthDay counts from the
+	// end of the month when negative.
+	ByMonth    []time.Month
+	ByMonthDay []int
+	ByDay      []WeekdayRule
+	// BySetPos keeps the nth days selected in each period, counting from its end when negative.
+	BySetPos []int
+	// WeekStart is the first day of the weeks of a weekly Recurrence, Monday when unset.
+	WeekStart *time.Weekday
+	// RDates adds occurrences to the rule, ExDates removes the occurrences starting at these times.
+	RDates  []time.Time
+	ExDates []time.Time
+}
+
+// ParseRRule reads the value of an RRULE property like FREQ=MONTHLY;BYDAY=2TU. A floating UNTIL is
+// read in loc.
+func ParseRRule(value string, loc *time.Location) (Recurrence, error) {
+	var r Recurrence
+	rrule := value
+	invalid := func(part string) error {
+		return fmt.Errorf("rrule: invalid %q in %q", part, rrule)
+	}
+	hasFrequency := false
+	for _, part := range strings.Split(rrule, ";") {
+		// the names and the values are case-insensitive
+		name, value, _ := strings.Cut(strings.ToUpper(part), "=")
+		var err error
+		if name == "" || strings.HasPrefix(name, "X-") {
+			// the experimental parts are ignored
+			continue
+		}
+		switch name {
+		case "FREQ":
+			frequencies := map[string]Frequency{
+				"DAILY": FrequencyDaily, "WEEKLY": FrequencyWeekly, "MONTHLY": FrequencyMonthly, "YEARLY": FrequencyYearly,
+			}
+			r.Frequency, hasFrequency = frequencies[value]
+			if !hasFrequency {
+				return r, fmt.Errorf("rrule: unsupported frequency %q", value)
+			}
+		case "INTERVAL":
+			if r.Interval, err = strconv.Atoi(value); err != nil || r.Interval < 1 {
+				return r, invalid(part)
+			}
+		case "COUNT":
+			if r.Count, err = strconv.Atoi(value); err != nil || r.Count < 1 {
+				return r, invalid(part)
+			}
+		case "UNTIL":
+			switch {
+			case len(value) == len("20060102"):
+				r.Until, err = time.ParseInLocation("20060102", value, loc)
+				// a date includes the occurrences of that day
+				r.Until = r.Until.AddDate(0, 0, 1).Add(-time.Nanosecond)
+			case strings.HasSuffix(value, "Z"):
+				r.Until, err = time.Parse("20060102T150405Z", value)
+			default:
+				r.Until, err = time.ParseInLocation("20060102T150405", value, loc)
+			}
+			if err != nil {
+				return r, invalid(part)
+			}
+		case "BYMONTH":
+			for _, v := range strings.Split(value, ",") {
+				month, err := strconv.Atoi(v)
+				if err != nil || month < 1 || month > 12 {
+					return r, invalid(part)
+				}
+				r.ByMonth = append(r.ByMonth, time.Month(month))
+			}
+		case "BYMONTHDAY":
+			for _, v := range strings.Split(value, ",") {
+				day, err := strconv.Atoi(v)
+				if err != nil || day == 0 || day < -31 || day > 31 {
+					return r, invalid(part)
+				}
+				r.ByMonthDay = append(r.ByMonthDay, day)
+			}
+		case "BYDAY":
+			for _, v := range strings.Split(value, ",") {
+				if len(v) < 2 {
+					return r, invalid(part)
+				}
+				rule := WeekdayRule{}
+				var ok bool
+				if rule.Weekday, ok = icsWeekdays[v[len(v)-2:]]; !ok {
+					return r, invalid(part)
+				}
+				if n := v[:len(v)-2]; n != "" {
+					if rule.N, err = strconv.Atoi(n); err != nil || rule.N == 0 || rule.N < -53 || rule.N > 53 {
+						return r, invalid(part)
+					}
+				}
+				r.ByDay = append(r.ByDay, rule)
+			}
+		case "BYSETPOS":
+			for _, v := range strings.Split(value, ",") {
+				pos, err := strconv.Atoi(v)
+				if err != nil || pos == 0 {
+					return r, invalid(part)
+				}
+				r.BySetPos = append(r.BySetPos, pos)
+			}
+		case "WKST":
+			weekday, ok := icsWeekdays[value]
+			if !ok {
+				return r, invalid(part)
+			}
+			r.WeekStart = &weekday
+		default:
+			return r, fmt.Errorf("rrule: unsupported %q in %q", part, rrule)
+		}
+	}
+	if !hasFrequency {
+		return r, fmt.Errorf("rrule: %q has no FREQ", rrule)
+	}
+	return r, nil
+}
+
+// Occurrences returns the occurrences of e overlapping the time from start up to but not including
+// end, e itself when it does not recur. The occurrences share the ID of e and have no Recurrence.
+func (e Event) Occurrences(start, end time.Time) []Event {
+	if e.Recurrence == nil {
+		if e.overlaps(start, end) {
+			return []Event{e}
+		}
+		return nil
+	}
+	r := e.Recurrence
+	excluded := func(t time.Time) bool {
+		for _, exDate := range r.ExDates {
+			if exDate.Equal(t) {
+				return true
+			}
+		}
+		return false
+	}
+	// occurrences starting before windowStart end before start
+	windowStart := start.Add(-e.End.Sub(e.Start))
+	if e.AllDay {
+		windowStart = start.AddDate(0, 0, -e.spannedDays())
+	}
+	var events []Event
+	add := func(t time.Time) {
+		for _, o := range events {
+			if o.Start.Equal(t) {
+				return
+			}
+		}
+		if !excluded(t) {
+			o := e.movedTo(t)
+			o.Recurrence = nil
+			if o.overlaps(start, end) {
+				events = append(events, o)
+			}
+		}
+	}
+	r.expand(e.Start, windowStart, end, func(t time.Time) bool {
+		if !t.Before(windowStart) {
+			add(t)
+		}
+		return true
+	})
+	for _, rDate := range r.RDates {
+		if !rDate.Before(windowStart) && rDate.Before(end) {
+			add(rDate.In(e.Start.Location()))
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Start.Before(events[j].Start)
+	})
+	return events
+}
+
+// spannedDays returns the number of days spanned by the all-day event e, at least one.
+func (e Event) spannedDays() int {
+	year, month, day := e.Start.Date()
+	start := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	year, month, day = e.End.Date()
+	days := int(time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Sub(start) / (24 * time.Hour))
+	if days < 1 {
+		return 1
+	}
+	return days
+}
+
+// expand calls yield with the starts of the occurrences of the rule from dtStart, in order, until
+// end, the Count or the Until of the rule is reached or yield returns false. dtStart is always the
+// first occurrence. Without a Count, the periods ending before from are skipped.
+func (r *Recurrence) expand(dtStart, from, end time.Time, yield func(t time.Time) bool) {
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+	count := 0
+	emit := func(t time.Time) bool {
+		if !r.Until.IsZero() && t.After(r.Until) || !t.Before(end) || r.Count > 0 && count >= r.Count {
+			return false
+		}
+		count++
+		return yield(t)
+	}
+	if !emit(dtStart) {
+		return
+	}
+	loc := dtStart.Location()
+	year, month, day := dtStart.Date()
+	hour, minute, second := dtStart.Clock()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, hour, minute, second, dtStart.Nanosecond(), loc)
+	}
+	// the periods are counted on dates in UTC, which have no daylight saving changes
+	weekStart := time.Monday
+	if r.WeekStart != nil {
+		weekStart = *r.WeekStart
+	}
+	startOfWeek := func(d time.Time) time.Time {
+		return d.AddDate(0, 0, -(int(d.Weekday())-int(weekStart)+7)%7)
+	}
+	firstWeek := startOfWeek(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+	firstPeriod := 0
+	if r.Count == 0 && from.After(dtStart) {
+		// the occurrences of the periods before the one holding from all start before it
+		fromYear, fromMonth, fromDay := from.In(loc).Date()
+		fromDate := time.Date(fromYear, fromMonth, fromDay, 0, 0, 0, 0, time.UTC)
+		var periods int
+		switch r.Frequency {
+		case FrequencyDaily:
+			periods = int(fromDate.Sub(time.Date(year, month, day, 0, 0, 0, 0, time.UTC)) / (24 * time.Hour))
+		case FrequencyWeekly:
+			periods = int(startOfWeek(fromDate).Sub(firstWeek) / (7 * 24 * time.Hour))
+		case FrequencyMonthly:
+			periods = (fromYear-year)*12 + int(fromMonth-month)
+		case FrequencyYearly:
+			periods = fromYear - year
+		}
+		firstPeriod = periods / interval * interval
+	}
+	for period := firstPeriod; ; period += interval {
+		var days []time.Time
+		var periodStart time.Time
+		switch r.Frequency {
+		case FrequencyDaily:
+			periodStart = time.Date(year, month, day+period, 0, 0, 0, 0, time.UTC)
+			days = r.filter([]time.Time{periodStart}, true, true)
+		case FrequencyWeekly:
+			periodStart = firstWeek.AddDate(0, 0, 7*period)
+			weekdays := []WeekdayRule{{Weekday: dtStart.Weekday()}}
+			if len(r.ByDay) > 0 {
+				weekdays = r.ByDay
+			}
+			for _, rule := range weekdays {
+				days = append(days, periodStart.AddDate(0, 0, (int(rule.Weekday)-int(weekStart)+7)%7))
+			}
+			days = r.filter(days, false, false)
+		case FrequencyMonthly:
+			periodStart = time.Date(year, month+time.Month(period), 1, 0, 0, 0, 0, time.UTC)
+			days = r.filter(r.monthDays(periodStart.Year(), periodStart.Month(), day), false, false)
+		case FrequencyYearly:
+			periodStart = time.Date(year+period, time.January, 1, 0, 0, 0, 0, time.UTC)
+			days = r.yearDays(year+period, month, day)
+		}
+		if last := end.In(loc); !periodStart.Before(time.Date(last.Year(), last.Month(), last.Day()+1, 0, 0, 0, 0, time.UTC)) {
+			return
+		}
+		for _, d := range r.setPositions(days) {
+			t := at(d.Year(), d.Month(), d.Day())
+			if !t.After(dtStart) {
+				continue
+			}
+			if !emit(t) {
+				return
+			}
+		}
+	}
+}
+
+// monthDays returns the days of month selected by ByMonthDay and ByDay, the day of the start when
+// there is neither.
+func (r *Recurrence) monthDays(year int, month time.Month, startDay int) []time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	length := first.AddDate(0, 1, -1).Day()
+	var days []time.Time
+	switch {
+	case len(r.ByMonthDay) > 0:
+		for _, n := range r.ByMonthDay {
+			if n < 0 {
+				n += length + 1
+			}
+			if n >= 1 && n <= length {
+				days = append(days, first.AddDate(0, 0, n-1))
+			}
+		}
+		if len(r.ByDay) > 0 {
+			days = r.filter(days, true, false)
+		}
+	case len(r.ByDay) > 0:
+		days = weekdaysIn(first, length, r.ByDay)
+	default:
+		if startDay <= length {
+			days = append(days, first.AddDate(0, 0, startDay-1))
+		}
+	}
+	return days
+}
+
+// yearDays returns the days of year selected by the rule, the months of ByMonth or, without them,
+// the months of ByMonthDay or the weeks of ByDay throughout the year.
+func (r *Recurrence) yearDays(year int, startMonth time.Month, startDay int) []time.Time {
+	if len(r.ByMonth) == 0 && len(r.ByMonthDay) == 0 && len(r.ByDay) > 0 {
+		first := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		return weekdaysIn(first, first.AddDate(1, 0, -1).YearDay(), r.ByDay)
+	}
+	months := r.ByMonth
+	if len(months) == 0 {
+		months = []time.Month{startMonth}
+		if len(r.ByMonthDay) > 0 {
+			months = []time.Month{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+		}
+	}
+	var days []time.Time
+	for _, month := range months {
+		days = append(days, r.monthDays(year, month, startDay)...)
+	}
+	return days
+}
+
+// weekdaysIn returns the days of the length days from first matching rules, their N counting
+// within these days.
+func weekdaysIn(first time.Time, length int, rules []WeekdayRule) []time.Time {
+	var days []time.Time
+	for _, rule := range rules {
+		offset := (int(rule.Weekday) - int(first.Weekday()) + 7) % 7
+		var matches []int
+		for ; offset < length; offset += 7 {
+			matches = append(matches, offset)
+		}
+		switch {
+		case rule.N == 0:
+		case rule.N > 0 && rule.N <= len(matches):
+			matches = matches[rule.N-1 : rule.N]
+		case rule.N < 0 && -rule.N <= len(matches):
+			matches = matches[len(matches)+rule.N : len(matches)+rule.N+1]
+		default:
+			matches = nil
+		}
+		for _, offset := range matches {
+			days = append(days, first.AddDate(0, 0, offset))
+		}
+	}
+	return days
+}
+
+// filter keeps the days within ByMonth and, when asked, matching the weekdays of ByDay or the
+// days of ByMonthDay.
+func (r *Recurrence) filter(days []time.Time, byDay, byMonthDay bool) []time.Time {
+	var kept []time.Time
+	for _, d := range days {
+		ok := len(r.ByMonth) == 0
+		for _, month := range r.ByMonth {
+			ok = ok || d.Month() == month
+		}
+		if byDay && len(r.ByDay) > 0 {
+			matches := false
+			for _, rule := range r.ByDay {
+				matches = matches || d.Weekday() == rule.Weekday
+			}
+			ok = ok && matches
+		}
+		if byMonthDay && len(r.ByMonthDay) > 0 {
+			length := time.Date(d.Year(), d.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+			matches := false
+			for _, n := range r.ByMonthDay {
+				matches = matches || d.Day() == n || d.Day() == n+length+1
+			}
+			ok = ok && matches
+		}
+		if ok {
+			kept = append(kept, d)
+		}
+	}
+	return kept
+}
+
+// setPositions sorts the days of a period, removing the duplicates, and keeps those of BySetPos.
+func (r *Recurrence) setPositions(days []time.Time) []time.Time {
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Before(days[j])
+	})
+	unique := days[:0]
+	for i, d := range days {
+		if i == 0 || !d.Equal(days[i-1]) {
+			unique = append(unique, d)
+		}
+	}
+	if len(r.BySetPos) == 0 {
+		return unique
+	}
+	var kept []time.Time
+	for _, pos := range r.BySetPos {
+		if pos < 0 {
+			pos += len(unique) + 1
+		}
+		if pos >= 1 && pos <= len(unique) {
+			kept = append(kept, unique[pos-1])
+		}
+	}
+	sort.Slice(kept, func(i, j int) bool {
+		return kept[i].Before(kept[j])
+	})
+	return kept
+}
@@ -0,0 +1,233 @@
+package giowidgets
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func newYork(t *testing.T) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	return loc
+}
+
+// occurrenceDates returns the days of the occurrences of the rule starting at dtStart, in the
+// window from dtStart up to end.
+func occurrenceDates(t *testing.T, rule string, dtStart, end time.Time) []string {
+	t.Helper()
+	recurrence, err := ParseRRule(rule, dtStart.Location())
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := Event{Start: dtStart, End: dtStart.Add(time.Hour), Recurrence: &recurrence}
+	var dates []string
+	for _, o := range event.Occurrences(dtStart, end) {
+		if hour, minute, _ := o.Start.Clock(); hour != dtStart.Hour() || minute != dtStart.Minute() {
+			t.Errorf("%s: occurrence at %v, want the wall clock of %v", rule, o.Start, dtStart)
+		}
+		dates = append(dates, o.Start.Format("2006-01-02"))
+	}
+	return dates
+}
+
+// TestRecurrenceRFC5545 expands the examples of RFC 5545 section 3.8.5.3, in America/New_York.
+func TestRecurrenceRFC5545(t *testing.T) {
+	loc := newYork(t)
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 9, 0, 0, 0, loc)
+	}
+	end := time.Date(2001, time.January, 1, 0, 0, 0, 0, loc)
+	tests := []struct {
+		rule    string
+		dtStart time.Time
+		end     time.Time
+		want    string
+	}{
+		{
+			"FREQ=DAILY;COUNT=10", at(1997, time.September, 2), end,
+			"1997-09-02 1997-09-03 1997-09-04 1997-09-05 1997-09-06 1997-09-07 1997-09-08 1997-09-09 1997-09-10 1997-09-11",
+		},
+		{
+			"FREQ=DAILY;INTERVAL=10;COUNT=5", at(1997, time.September, 2), end,
+			"1997-09-02 1997-09-12 1997-09-22 1997-10-02 1997-10-12",
+		},
+		{
+			// crosses the end of daylight saving time on October 26
+			"FREQ=DAILY;UNTIL=19971030T000000Z", at(1997, time.October, 24), end,
+			"1997-10-24 1997-10-25 1997-10-26 1997-10-27 1997-10-28 1997-10-29",
+		},
+		{
+			"FREQ=WEEKLY;COUNT=10", at(1997, time.September, 2), end,
+			"1997-09-02 1997-09-09 1997-09-16 1997-09-23 1997-09-30 1997-10-07 1997-10-14 1997-10-21 1997-10-28 1997-11-04",
+		},
+		{
+			"FREQ=WEEKLY;UNTIL=19971007T000000Z;WKST=SU;BYDAY=TU,TH", at(1997, time.September, 2), end,
+			"1997-09-02 1997-09-04 1997-09-09 1997-09-11 1997-09-16 1997-09-18 1997-09-23 1997-09-25 1997-09-30 1997-10-02",
+		},
+		{
+			"FREQ=WEEKLY;INTERVAL=2;UNTIL=19971224T000000Z;WKST=SU;BYDAY=MO,WE,FR", at(1997, time.September, 1), end,
+			"1997-09-01 1997-09-03 1997-09-05 1997-09-15 1997-09-17 1997-09-19 1997-09-29 1997-10-01 1997-10-03 " +
+				"1997-10-13 1997-10-15 1997-10-17 1997-10-27 1997-10-29 1997-10-31 1997-11-10 1997-11-12 1997-11-14 " +
+				"1997-11-24 1997-11-26 1997-11-28 1997-12-08 1997-12-10 1997-12-12 1997-12-22",
+		},
+		{
+			"FREQ=MONTHLY;COUNT=10;BYDAY=1FR", at(1997, time.September, 5), end,
+			"1997-09-05 1997-10-03 1997-11-07 1997-12-05 1998-01-02 1998-02-06 1998-03-06 1998-04-03 1998-05-01 1998-06-05",
+		},
+		{
+			"FREQ=MONTHLY;COUNT=6;BYDAY=-2MO", at(1997, time.September, 22), end,
+			"1997-09-22 1997-10-20 1997-11-17 1997-12-22 1998-01-19 1998-02-16",
+		},
+		{
+			"FREQ=MONTHLY;BYMONTHDAY=-3", at(1997, time.September, 28), at(1998, time.March, 1),
+			"1997-09-28 1997-10-29 1997-11-28 1997-12-29 1998-01-29 1998-02-26",
+		},
+		{
+			"FREQ=MONTHLY;COUNT=10;BYMONTHDAY=2,15", at(1997, time.September, 2), end,
+			"1997-09-02 1997-09-15 1997-10-02 1997-10-15 1997-11-02 1997-11-15 1997-12-02 1997-12-15 1998-01-02 1998-01-15",
+		},
+		{
+			"FREQ=MONTHLY;COUNT=10;BYMONTHDAY=1,-1", at(1997, time.September, 30), end,
+			"1997-09-30 1997-10-01 1997-10-31 1997-11-01 1997-11-30 1997-12-01 1997-12-31 1998-01-01 1998-01-31 1998-02-01",
+		},
+		{
+			// the invalid dates are skipped
+			"FREQ=MONTHLY;BYMONTHDAY=15,30;COUNT=5", at(2007, time.January, 15), at(2008, time.January, 1),
+			"2007-01-15 2007-01-30 2007-02-15 2007-03-15 2007-03-30",
+		},
+		{
+			"FREQ=MONTHLY;COUNT=4", at(2007, time.January, 31), at(2008, time.January, 1),
+			"2007-01-31 2007-03-31 2007-05-31 2007-07-31",
+		},
+		{
+			"FREQ=MONTHLY;COUNT=3;BYDAY=TU,WE,TH;BYSETPOS=3", at(1997, time.September, 4), end,
+			"1997-09-04 1997-10-07 1997-11-06",
+		},
+		{
+			"FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-2", at(1997, time.September, 29), at(1998, time.April, 1),
+			"1997-09-29 1997-10-30 1997-11-27 1997-12-30 1998-01-29 1998-02-26 1998-03-30",
+		},
+		{
+			"FREQ=YEARLY;COUNT=10;BYMONTH=6,7", at(1997, time.June, 10), end,
+			"1997-06-10 1997-07-10 1998-06-10 1998-07-10 1999-06-10 1999-07-10 2000-06-10 2000-07-10",
+		},
+		{
+			"FREQ=YEARLY;BYDAY=20MO", at(1997, time.May, 19), end,
+			"1997-05-19 1998-05-18 1999-05-17 2000-05-15",
+		},
+		{
+			"FREQ=YEARLY;BYMONTH=3;BYDAY=TH", at(1997, time.March, 13), at(1998, time.January, 1),
+			"1997-03-13 1997-03-20 1997-03-27",
+		},
+		{
+			"FREQ=YEARLY;INTERVAL=4;BYMONTH=11;BYDAY=TU;BYMONTHDAY=2,3,4,5,6,7,8", at(1996, time.November, 5), end,
+			"1996-11-05 2000-11-07",
+		},
+	}
+	for _, test := range tests {
+		got := strings.Join(occurrenceDates(t, test.rule, test.dtStart, test.end), " ")
+		if got != test.want {
+			t.Errorf("%s from %v:\n got %s\nwant %s", test.rule, test.dtStart.Format("2006-01-02"), got, test.want)
+		}
+	}
+}
+
+func TestRecurrenceFridayThe13th(t *testing.T) {
+	loc := newYork(t)
+	dtStart := time.Date(1997, time.September, 2, 9, 0, 0, 0, loc)
+	recurrence, err := ParseRRule("FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13", loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the EXDATE of the example removes DTSTART, which is not a Friday the 13th
+	recurrence.ExDates = []time.Time{dtStart}
+	event := Event{Start: dtStart, End: dtStart.Add(time.Hour), Recurrence: &recurrence}
+	var got []string
+	for _, o := range event.Occurrences(dtStart, time.Date(2001, time.January, 1, 0, 0, 0, 0, loc)) {
+		got = append(got, o.Start.Format("2006-01-02"))
+	}
+	want := "1998-02-13 1998-03-13 1998-11-13 1999-08-13 2000-10-13"
+	if strings.Join(got, " ") != want {
+		t.Errorf("got %v, want %s", got, want)
+	}
+}
+
+// TestRecurrenceWindow compares the occurrences in a window far from the start with those
+// expanded from the start.
+func TestRecurrenceWindow(t *testing.T) {
+	loc := newYork(t)
+	dtStart := time.Date(2000, time.January, 31, 23, 30, 0, 0, loc)
+	windowStart := time.Date(2024, time.March, 1, 0, 0, 0, 0, loc)
+	windowEnd := time.Date(2024, time.May, 1, 0, 0, 0, 0, loc)
+	for _, rule := range []string{
+		"FREQ=DAILY",
+		"FREQ=DAILY;INTERVAL=3",
+		"FREQ=WEEKLY;INTERVAL=2;BYDAY=SU,SA",
+		"FREQ=WEEKLY;INTERVAL=5;WKST=SU",
+		"FREQ=MONTHLY;INTERVAL=7",
+		"FREQ=MONTHLY;INTERVAL=2;BYMONTHDAY=-1,1",
+		"FREQ=YEARLY;INTERVAL=3;BYMONTH=3,4;BYDAY=-1SU",
+		"FREQ=DAILY;UNTIL=20240315T000000Z",
+	} {
+		recurrence, err := ParseRRule(rule, loc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var want []string
+		recurrence.expand(dtStart, dtStart, windowEnd, func(t time.Time) bool {
+			if !t.Before(windowStart.Add(-time.Hour)) {
+				want = append(want, t.Format(time.RFC3339))
+			}
+			return true
+		})
+		event := Event{Start: dtStart, End: dtStart.Add(time.Hour), Recurrence: &recurrence}
+		var got []string
+		for _, o := range event.Occurrences(windowStart, windowEnd) {
+			got = append(got, o.Start.Format(time.RFC3339))
+		}
+		if strings.Join(got, " ") != strings.Join(want, " ") {
+			t.Errorf("%s:\n got %v\nwant %v", rule, got, want)
+		}
+	}
+}
+
+func TestRecurrenceICS(t *testing.T) {
+	loc := newYork(t)
+	events := parseTestICS(t,
+		"BEGIN:VEVENT",
+		"UID:standup",
+		"SUMMARY:Standup",
+		"DTSTART;TZID=America/New_York:20240304T090000",
+		"DTEND;TZID=America/New_York:20240304T091500",
+		"RRULE:FREQ=WEEKLY;COUNT=4",
+		"EXDATE;TZID=America/New_York:20240311T090000",
+		"RDATE;TZID=America/New_York:20240402T090000,20240403T090000",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:standup",
+		"SUMMARY:Standup moved",
+		"RECURRENCE-ID;TZID=America/New_York:20240318T090000",
+		"DTSTART;TZID=America/New_York:20240319T100000",
+		"DTEND;TZID=America/New_York:20240319T101500",
+		"END:VEVENT",
+	)
+	var got []string
+	for _, e := range events.Events(time.Date(2024, time.March, 1, 0, 0, 0, 0, loc), time.Date(2024, time.May, 1, 0, 0, 0, 0, loc)) {
+		got = append(got, e.Start.In(loc).Format("01-02 15:04 ")+e.Title)
+	}
+	want := []string{
+		"03-04 09:00 Standup",
+		// March 11 is excluded, March 18 is replaced by the moved instance
+		"03-25 09:00 Standup",
+		"04-02 09:00 Standup",
+		"04-03 09:00 Standup",
+		"03-19 10:00 Standup moved",
+	}
+	if strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,274 @@
+package giowidgets
+
+import (
+	"fmt"
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/text"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+	"golang.org/x/exp/shiny/materialdesign/icons"
+	"golang.org/x/text/language"
+	"image"
+	"image/color"
+	"strings"
+	"time"
+)
+
+// OnTimeChange is called with the time edited in a TimePicker or a DateTimePicker.
+type OnTimeChange func(t time.Time)
+
+// HourCycle chooses between the 24-hour clock and the 12-hour clock with an AM/PM toggle.
+type HourCycle int
+
+const (
+	// HourCycleLocale follows the TimeLayout of the Locale.
+	HourCycleLocale HourCycle = iota
+	HourCycle24
+	HourCycle12
+)
+
+// timeField is a spinner of the TimePicker.
+type timeField int
+
+const (
+	fieldHour timeField = iota
+	fieldMinute
+	fieldSecond
+)
+
+// TimePicker edits the time of the day of a time.Time with spinners, keeping its day. It holds the
+// state and is drawn by a TimePickerStyle.
+type TimePicker struct {
+	HourCycle HourCycle
+	// Locale chooses the hour cycle of HourCycleLocale, the zero value uses American English.
+	Locale      language.Tag
+	ShowSeconds bool
+	// MinuteStep is the step of the minute spinner, it should divide 60. Zero steps by one minute.
+	MinuteStep int
+	// Clock and Location give the day of a picker whose time was never set, nil uses SystemClock
+	// and the local time zone.
+	Clock    Clock
+	Location *time.Location
+	OnTimeChange
+	time time.Time
+	// spinners holds the up and down buttons of the hour, minute and second spinners
+	spinners    [3][2]widget.Clickable
+	btnMeridiem widget.Clickable
+}
+
+// Time returns the edited time, midnight of today when it was never set.
+func (p *TimePicker) Time() time.Time {
+	if p.time.IsZero() {
+		clock, loc := p.Clock, p.Location
+		if clock == nil {
+			clock = SystemClock
+		}
+		if loc == nil {
+			loc = time.Local
+		}
+		year, month, day := clock.Now().In(loc).Date()
+		p.time = time.Date(year, month, day, 0, 0, 0, 0, loc)
+	}
+	return p.time
+}
+
+func (p *TimePicker) SetTime(t time.Time) {
+	p.time = t
+}
+
+// hour12 reports whether the hours are displayed from 1 to 12 with an AM/PM toggle.
+func (p *TimePicker) hour12() bool {
+	switch p.HourCycle {
+	case HourCycle12:
+		return true
+	case HourCycle24:
+		return false
+	}
+	return strings.Contains(LocaleFor(p.Locale).TimeLayout, "PM")
+}
+
+func (p *TimePicker) minuteStep() int {
+	if p.MinuteStep < 1 || p.MinuteStep > 60 {
+		return 1
+	}
+	return p.MinuteStep
+}
+
+// setTime records t, calling OnTimeChange when it differs from the previous time.
+func (p *TimePicker) setTime(t time.Time) {
+	changed := !t.Equal(p.Time())
+	p.time = t
+	if changed && p.OnTimeChange != nil {
+		p.OnTimeChange(t)
+	}
+}
+
+// step moves field by one step up when dir is 1, down when it is -1. The fields wrap around
+// without changing the others. The time moves by elapsed time, so that the hours skipped or
+// repeated by a change of daylight saving time are stepped through.
+func (p *TimePicker) step(field timeField, dir int) {
+	t := p.Time()
+	t = t.Add(-time.Duration(t.Nanosecond()))
+	hour, minute, second := t.Clock()
+	wrap := func(n, length int) int {
+		return (n%length + length) % length
+	}
+	switch field {
+	case fieldHour:
+		if next := t.Add(time.Duration(dir) * time.Hour); sameDay(next, t) {
+			p.setTime(next)
+			return
+		}
+		// the hours wrap around within the day
+		year, month, day := t.Date()
+		p.setTime(time.Date(year, month, day, wrap(hour+dir, 24), minute, second, 0, t.Location()))
+	case fieldMinute:
+		step := p.minuteStep()
+		if dir < 0 && minute%step != 0 {
+			// a minute between the steps first goes down to the previous step
+			dir = 0
+		}
+		p.setTime(t.Add(time.Duration(wrap((minute/step+dir)*step, 60)-minute) * time.Minute))
+	case fieldSecond:
+		p.setTime(t.Add(time.Duration(wrap(second+dir, 60)-second) * time.Second))
+	}
+}
+
+// update handles the clicks of the spinners and of the AM/PM toggle.
+func (p *TimePicker) update() {
+	for field := range p.spinners {
+		if p.spinners[field][0].Clicked() {
+			p.step(timeField(field), 1)
+		}
+		if p.spinners[field][1].Clicked() {
+			p.step(timeField(field), -1)
+		}
+	}
+	if p.btnMeridiem.Clicked() {
+		t := p.Time()
+		year, month, day := t.Date()
+		hour, minute, second := t.Clock()
+		p.setTime(time.Date(year, month, day, (hour+12)%24, minute, second, 0, t.Location()))
+	}
+}
+
+// TimePickerStyle draws a TimePicker. It is built by NewTimePickerStyle and can be adjusted before
+// calling Layout.
+type TimePickerStyle struct {
+	TimePicker *TimePicker
+	Theme      *material.Theme
+	// TextSize is the size of the digits.
+	TextSize  unit.Sp
+	Fg        color.NRGBA
+	IconColor color.NRGBA
+	// MeridiemBg and MeridiemFg color the AM/PM toggle.
+	MeridiemBg color.NRGBA
+	MeridiemFg color.NRGBA
+	// SpinnerWidth is the width of the spinners, and of their buttons.
+	SpinnerWidth unit.Dp
+}
+
+// NewTimePickerStyle returns the style drawing picker with the colors of th.
+func NewTimePickerStyle(th *material.Theme, picker *TimePicker) TimePickerStyle {
+	return TimePickerStyle{
+		TimePicker:   picker,
+		Theme:        th,
+		TextSize:     th.TextSize * 2,
+		Fg:           th.Fg,
+		IconColor:    th.ContrastBg,
+		MeridiemBg:   th.ContrastBg,
+		MeridiemFg:   th.ContrastFg,
+		SpinnerWidth: unit.Dp(56),
+	}
+}
+
+func (s TimePickerStyle) Layout(gtx Gtx) Dim {
+	p := s.TimePicker
+	p.update()
+	hour, minute, second := p.Time().Clock()
+	hourLabel := fmt.Sprintf("%02d", hour)
+	if p.hour12() {
+		hourLabel = fmt.Sprintf("%d", (hour+11)%12+1)
+	}
+	separator := layout.Rigid(func(gtx Gtx) Dim {
+		label := material.Label(s.Theme, s.TextSize, ":")
+		label.Color = s.Fg
+		return label.Layout(gtx)
+	})
+	flexChildren := []FlexChild{
+		s.spinner(fieldHour, hourLabel),
+		separator,
+		s.spinner(fieldMinute, fmt.Sprintf("%02d", minute)),
+	}
+	if p.ShowSeconds {
+		flexChildren = append(flexChildren, separator, s.spinner(fieldSecond, fmt.Sprintf("%02d", second)))
+	}
+	if p.hour12() {
+		meridiem := "AM"
+		if hour >= 12 {
+			meridiem = "PM"
+		}
+		flexChildren = append(flexChildren, layout.Rigid(func(gtx Gtx) Dim {
+			inset := Inset{Left: 12}
+			return inset.Layout(gtx, func(gtx Gtx) Dim {
+				return p.btnMeridiem.Layout(gtx, func(gtx Gtx) Dim {
+					return layout.Stack{}.Layout(gtx,
+						layout.Expanded(func(gtx Gtx) Dim {
+							rect := image.Rectangle{Max: gtx.Constraints.Min}
+							paint.FillShape(gtx.Ops, s.MeridiemBg, clip.UniformRRect(rect, gtx.Dp(4)).Op(gtx.Ops))
+							return Dim{Size: rect.Max}
+						}),
+						layout.Stacked(func(gtx Gtx) Dim {
+							inset := Inset{Top: 6, Bottom: 6, Left: 10, Right: 10}
+							return inset.Layout(gtx, func(gtx Gtx) Dim {
+								label := material.Label(s.Theme, s.Theme.TextSize, meridiem)
+								label.Color = s.MeridiemFg
+								return label.Layout(gtx)
+							})
+						}),
+					)
+				})
+			})
+		}))
+	}
+	flex := Flex{Alignment: layout.Middle}
+	return flex.Layout(gtx, flexChildren...)
+}
+
+// spinner draws the value of field between the buttons stepping it up and down.
+func (s TimePickerStyle) spinner(field timeField, value string) FlexChild {
+	return layout.Rigid(func(gtx Gtx) Dim {
+		width := gtx.Dp(s.SpinnerWidth)
+		button := func(btn *widget.Clickable, iconData []byte) FlexChild {
+			return layout.Rigid(func(gtx Gtx) Dim {
+				return btn.Layout(gtx, func(gtx Gtx) Dim {
+					gtx.Constraints.Min = image.Point{X: width, Y: width / 2}
+					gtx.Constraints.Max = gtx.Constraints.Min
+					return layout.Center.Layout(gtx, func(gtx Gtx) Dim {
+						size := width / 2
+						gtx.Constraints.Min = image.Point{X: size, Y: size}
+						gtx.Constraints.Max = gtx.Constraints.Min
+						icon, _ := widget.NewIcon(iconData)
+						return icon.Layout(gtx, s.IconColor)
+					})
+				})
+			})
+		}
+		buttons := &s.TimePicker.spinners[field]
+		flex := Flex{Axis: layout.Vertical, Alignment: layout.Middle}
+		return flex.Layout(gtx,
+			button(&buttons[0], icons.NavigationExpandLess),
+			layout.Rigid(func(gtx Gtx) Dim {
+				gtx.Constraints.Min.X = width
+				label := material.Label(s.Theme, s.TextSize, value)
+				label.Color = s.Fg
+				label.Alignment = text.Middle
+				return label.Layout(gtx)
+			}),
+			button(&buttons[1], icons.NavigationExpandMore),
+		)
+	})
+}
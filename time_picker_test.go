@@ -0,0 +1,47 @@
+package giowidgets
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestTimePickerStepDST steps the hours through the changes of daylight saving time in New York.
+func TestTimePickerStepDST(t *testing.T) {
+	loc := newYork(t)
+	tests := []struct {
+		start time.Time
+		dir   int
+		want  string
+	}{
+		// 2:00 to 3:00 is skipped on March 10, 2024
+		{time.Date(2024, time.March, 10, 0, 30, 0, 0, loc), 1, "01:30 EST, 03:30 EDT, 04:30 EDT"},
+		{time.Date(2024, time.March, 10, 4, 30, 0, 0, loc), -1, "03:30 EDT, 01:30 EST, 00:30 EST"},
+		// 1:00 to 2:00 is repeated on November 3, 2024
+		{time.Date(2024, time.November, 3, 0, 30, 0, 0, loc), 1, "01:30 EDT, 01:30 EST, 02:30 EST"},
+		{time.Date(2024, time.November, 3, 2, 30, 0, 0, loc), -1, "01:30 EST, 01:30 EDT, 00:30 EDT"},
+	}
+	for _, test := range tests {
+		p := TimePicker{}
+		p.SetTime(test.start)
+		var got []string
+		for i := 0; i < 3; i++ {
+			p.step(fieldHour, test.dir)
+			got = append(got, p.Time().Format("15:04 MST"))
+		}
+		if strings.Join(got, ", ") != test.want {
+			t.Errorf("from %v by %d: got %v, want %s", test.start, test.dir, got, test.want)
+		}
+	}
+	// the hours still wrap around within the day
+	p := TimePicker{}
+	p.SetTime(time.Date(2024, time.March, 10, 0, 30, 0, 0, loc))
+	p.step(fieldHour, -1)
+	if got := p.Time(); !got.Equal(time.Date(2024, time.March, 10, 23, 30, 0, 0, loc)) {
+		t.Errorf("an hour before 00:30: %v, want 23:30 the same day", got)
+	}
+	p.step(fieldMinute, 1)
+	if got := p.Time(); !got.Equal(time.Date(2024, time.March, 10, 23, 31, 0, 0, loc)) {
+		t.Errorf("a minute after 23:30: %v, want 23:31", got)
+	}
+}